@@ -16,6 +16,7 @@ package v1alpha1
 
 import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 // Component represents a Component name within a Konflux Application.
@@ -52,12 +53,88 @@ type NamespaceSpec struct {
 	Applications []ApplicationSpec `json:"applications,omitempty"`
 }
 
+// ExcludedApplication identifies an Application which is not scanned.
+type ExcludedApplication struct {
+	// Namespace of the Application. If omitted, the Application is excluded in all namespaces.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Name of the Application.
+	// +required
+	Application string `json:"application"`
+}
+
+// ExcludedComponent identifies a Component which is not scanned.
+type ExcludedComponent struct {
+	// Namespace of the Component. If omitted, the Component is excluded in all namespaces.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Name of the Component.
+	// +required
+	Component Component `json:"component"`
+}
+
+// ExcludeSpec lists what is not scanned, even if it's selected by the other fields of the spec.
+type ExcludeSpec struct {
+	// Names of the namespaces to exclude.
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// Applications to exclude.
+	// +optional
+	Applications []ExcludedApplication `json:"applications,omitempty"`
+
+	// Components to exclude.
+	// +optional
+	Components []ExcludedComponent `json:"components,omitempty"`
+
+	// Glob patterns matched against the `<host>/<repository>` of the git repository,
+	// e.g. `github.com/org/legacy-*`. A `*` doesn't match a `/`. Bitbucket
+	// repositories are matched as `<host>/<PROJECT>/<repo>`, whatever the URL.
+	// +optional
+	Repositories []string `json:"repositories,omitempty"`
+}
+
+// RepositorySpec is a git repository scanned without a Konflux Component.
+type RepositorySpec struct {
+	// URL of the git repository, e.g. https://github.com/org/repo.
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	URL string `json:"url"`
+
+	// Branches to scan. If omitted, the default branch of the repository is scanned.
+	// A branch can be a glob, e.g. release-*, or a regular expression enclosed
+	// in slashes, e.g. /^release-\d+$/, matching the branches of the repository.
+	// +optional
+	Branches []string `json:"branches,omitempty"`
+
+	// Namespace of the Secrets used to access the repository on platforms
+	// which don't use the GitHub App, e.g. GitLab. If omitted, the namespace
+	// of the DependencyUpdateCheck is used.
+	// +optional
+	CredentialsNamespace string `json:"credentialsNamespace,omitempty"`
+}
+
+// UpdateMode selects which dependency updates Renovate proposes.
+// +kubebuilder:validation:Enum=All;VulnerabilityOnly
+type UpdateMode string
+
+const (
+	// UpdateModeAll proposes every update allowed by the Renovate configuration.
+	UpdateModeAll UpdateMode = "All"
+	// UpdateModeVulnerabilityOnly only proposes updates which fix known vulnerabilities,
+	// routine version bumps are suppressed.
+	UpdateModeVulnerabilityOnly UpdateMode = "VulnerabilityOnly"
+)
+
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
 
 // DependencyUpdateCheckSpec filters which Konflux Components will be scanned.
-// If `namespaces` is empty, MintMaker scans all Components discoverable to the controller.
+// If `namespaces` and `namespaceSelector` are empty, MintMaker scans all Components discoverable to the controller.
 // If provided, MintMaker only scans Components that match the namespace/application/component filters.
+// `componentSelector` and `applicationSelector` further narrow down the scanned Components.
 type DependencyUpdateCheckSpec struct {
 	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
 	// Important: Run "make" to regenerate code after modifying this file
@@ -66,29 +143,346 @@ type DependencyUpdateCheckSpec struct {
 	// If omitted, MintMaker will run for all namespaces.
 	// +optional
 	Namespaces []NamespaceSpec `json:"namespaces,omitempty"`
+
+	// Selects namespaces by their labels, MintMaker runs for all components in
+	// the selected namespaces, in addition to the ones from `namespaces`.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// Limits the components to those whose labels match the selector.
+	// +optional
+	ComponentSelector *metav1.LabelSelector `json:"componentSelector,omitempty"`
+
+	// Limits the components to those belonging to an application whose labels match the selector.
+	// +optional
+	ApplicationSelector *metav1.LabelSelector `json:"applicationSelector,omitempty"`
+
+	// Git repositories to scan directly, without discovering them through Components.
+	// If only repositories are specified, no Components are scanned.
+	// +optional
+	Repositories []RepositorySpec `json:"repositories,omitempty"`
+
+	// Namespaces, applications, components and repositories which are not scanned.
+	// Excluded components are reported in the status.
+	// +optional
+	Exclude *ExcludeSpec `json:"exclude,omitempty"`
+
+	// Opaque value which can be changed to run the check again, e.g. to retry
+	// targets which failed. Any spec change starts a new run, this field only
+	// exists so that a run can be requested without changing the filters.
+	// +optional
+	RerunToken string `json:"rerunToken,omitempty"`
+
+	// Which updates Renovate proposes. With "VulnerabilityOnly", Renovate only opens
+	// pull requests fixing known vulnerabilities, based on the OSV database, and all
+	// routine version bumps are disabled. Components can also be switched to this mode
+	// by the `mintmaker.appstudio.redhat.com/update-mode` annotation.
+	// +kubebuilder:default=All
+	// +optional
+	UpdateMode UpdateMode `json:"updateMode,omitempty"`
+
+	// Renovate configuration deep-merged on top of the cluster-wide base configuration
+	// for every target of this check, e.g. `{"prConcurrentLimit": 20}`. Objects are merged
+	// key by key, `packageRules` are appended to the base rules and any other value
+	// replaces the base value. Values MintMaker sets per repository, such as
+	// `repositories` or `platform`, can't be overridden.
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Type=object
+	// +optional
+	RenovateOverrides *runtime.RawExtension `json:"renovateOverrides,omitempty"`
+
+	// If true, deleting the check cancels its PipelineRuns which haven't finished yet.
+	// The PipelineRuns are owned by the check, so they are garbage-collected with
+	// it, together with their Secrets and ConfigMaps. Note that the history limits
+	// of a DependencyUpdateSchedule delete old checks, and so their PipelineRuns too.
+	// +optional
+	CleanupOnDelete bool `json:"cleanupOnDelete,omitempty"`
+
+	// Time window over which the PipelineRuns are created, e.g. 6h. Each
+	// repository is given a deterministic offset in the window, its targets
+	// are queued until the start of the run plus the offset. This keeps the
+	// load on the git hosts and the cluster flat instead of spiky.
+	// +optional
+	SpreadOver *metav1.Duration `json:"spreadOver,omitempty"`
+
+	// If true, the controller discovers the targets and does every lookup needed
+	// to scan them (branches, tokens, Renovate configuration), but doesn't create
+	// any Secrets, ConfigMaps or PipelineRuns. The planned targets are reported in
+	// the status with the Planned state.
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+}
+
+// DependencyUpdateCheckPhase is a high-level summary of where the check is in its lifecycle.
+// +kubebuilder:validation:Enum=Running;Completed;Failed
+type DependencyUpdateCheckPhase string
+
+const (
+	// PhaseRunning means the controller is processing the targets of the current
+	// generation, or some of them are queued.
+	PhaseRunning DependencyUpdateCheckPhase = "Running"
+	// PhaseCompleted means every target of the current generation was scheduled or skipped.
+	PhaseCompleted DependencyUpdateCheckPhase = "Completed"
+	// PhaseFailed means at least one target of the current generation failed.
+	// Changing `spec.rerunToken` runs the check again.
+	PhaseFailed DependencyUpdateCheckPhase = "Failed"
+)
+
+// CredentialSource describes where the token used to access a repository comes from.
+// +kubebuilder:validation:Enum=GitHubApp;ScmSecret
+type CredentialSource string
+
+const (
+	// CredentialSourceGitHubApp means a token of an installation of the GitHub App is used.
+	CredentialSourceGitHubApp CredentialSource = "GitHubApp"
+	// CredentialSourceScmSecret means the token of an scm secret in the namespace
+	// of the Component is used, e.g. a personal access token.
+	CredentialSourceScmSecret CredentialSource = "ScmSecret"
+)
+
+// TargetState describes the outcome of a single repository+branch target.
+// +kubebuilder:validation:Enum=Pending;Scheduled;Planned;Queued;Failed;Skipped
+type TargetState string
+
+const (
+	// TargetStatePending means the target was discovered, but the controller
+	// hasn't looked up its branches yet. Pending targets have no branch and
+	// are replaced by a target per branch once they are processed.
+	TargetStatePending TargetState = "Pending"
+	// TargetStateScheduled means a PipelineRun was created for the target.
+	TargetStateScheduled TargetState = "Scheduled"
+	// TargetStatePlanned means a PipelineRun would have been created for the
+	// target, if the check wasn't a dry run.
+	TargetStatePlanned TargetState = "Planned"
+	// TargetStateQueued means the target waits for running PipelineRuns to
+	// finish, because the controller's limit of concurrent PipelineRuns is
+	// reached, or for its time in the window of `spec.spreadOver`.
+	TargetStateQueued TargetState = "Queued"
+	// TargetStateFailed means the target should have been scanned, but
+	// MintMaker could not create a PipelineRun for it.
+	TargetStateFailed TargetState = "Failed"
+	// TargetStateSkipped means the target was intentionally not scanned.
+	TargetStateSkipped TargetState = "Skipped"
+)
+
+// Reasons reported in TargetStatus.Reason.
+const (
+	// TargetReasonDisabled is used when the Component has MintMaker disabled by annotation.
+	TargetReasonDisabled = "Disabled"
+	// TargetReasonApplicationDisabled is used when the Application of the Component has MintMaker disabled by annotation.
+	TargetReasonApplicationDisabled = "ApplicationDisabled"
+	// TargetReasonNamespaceDisabled is used when the Namespace of the Component has MintMaker disabled by annotation.
+	TargetReasonNamespaceDisabled = "NamespaceDisabled"
+	// TargetReasonExcluded is used when the Component or its repository matches a rule in `spec.exclude`.
+	TargetReasonExcluded = "ExcludedByRule"
+	// TargetReasonNoBranches is used when none of the Component's versions is an existing branch.
+	TargetReasonNoBranches = "NoBranches"
+	// TargetReasonAppNotInstalled is used when the GitHub App is not installed for the repository.
+	TargetReasonAppNotInstalled = "AppNotInstalled"
+	// TargetReasonDuplicate is used when another Component already scheduled the same repository+branch.
+	TargetReasonDuplicate = "Duplicate"
+	// TargetReasonNotDue is used when the Component has a scan schedule by annotation and
+	// the repository+branch was scanned recently enough.
+	TargetReasonNotDue = "NotDue"
+	// TargetReasonAlreadyRunning is used when a PipelineRun for the same repository+branch,
+	// e.g. created by another check, hasn't finished yet.
+	TargetReasonAlreadyRunning = "AlreadyRunning"
+	// TargetReasonRetried is used for targets whose PipelineRun failed for a transient
+	// reason, e.g. an image pull failure, and which are scheduled again.
+	TargetReasonRetried = "Retried"
+	// TargetReasonRetriesExhausted is used when the PipelineRuns of a target kept failing
+	// for transient reasons until the controller's limit of attempts was reached.
+	TargetReasonRetriesExhausted = "RetriesExhausted"
+	// TargetReasonComponentError is used when the Component can't be turned into a git target,
+	// e.g. it has no git URL or its git platform is not supported.
+	TargetReasonComponentError = "ComponentError"
+	// TargetReasonPipelineRunFailed is used when creating the PipelineRun or its resources failed.
+	TargetReasonPipelineRunFailed = "PipelineRunCreationFailed"
+	// TargetReasonPlanningFailed is used in dry runs when one of the lookups needed
+	// for creating the PipelineRun failed.
+	TargetReasonPlanningFailed = "PlanningFailed"
+	// TargetReasonInvalidOverrides is used when `spec.renovateOverrides` is not a valid JSON object.
+	TargetReasonInvalidOverrides = "InvalidRenovateOverrides"
+)
+
+// Condition types reported in DependencyUpdateCheckStatus.Conditions.
+const (
+	// ConditionCompleted is True once the controller has processed every target of the check.
+	ConditionCompleted = "Completed"
+	// ConditionDegraded is True when at least one target failed.
+	ConditionDegraded = "Degraded"
+)
+
+// TargetStatus records what the controller did with a single repository+branch
+// of a Component. Targets which were skipped before their branches were known
+// (e.g. disabled Components) and pending targets have an empty branch.
+type TargetStatus struct {
+	// Namespace of the Component.
+	Namespace string `json:"namespace"`
+
+	// Application the Component belongs to.
+	// +optional
+	Application string `json:"application,omitempty"`
+
+	// Name of the Component.
+	// +optional
+	Component string `json:"component,omitempty"`
+
+	// Git host of the repository, e.g. github.com.
+	// +optional
+	Host string `json:"host,omitempty"`
+
+	// Path of the repository on the git host, e.g. konflux-ci/mintmaker.
+	// +optional
+	Repository string `json:"repository,omitempty"`
+
+	// Git URL of the repository.
+	// +optional
+	URL string `json:"url,omitempty"`
+
+	// Branch scanned by Renovate.
+	// +optional
+	Branch string `json:"branch,omitempty"`
+
+	// Update mode used for this target.
+	// +optional
+	UpdateMode UpdateMode `json:"updateMode,omitempty"`
+
+	// Source of the token used to access the repository.
+	// +optional
+	CredentialSource CredentialSource `json:"credentialSource,omitempty"`
+
+	// Time before which the PipelineRun of a queued target isn't created, set
+	// when the check spreads its PipelineRuns over a time window.
+	// +optional
+	NotBefore *metav1.Time `json:"notBefore,omitempty"`
+
+	// Name of the PipelineRun created for this target.
+	// +optional
+	PipelineRun string `json:"pipelineRun,omitempty"`
+
+	// Number of PipelineRuns created for this target, more than one if failed
+	// PipelineRuns were retried.
+	// +optional
+	Attempts int32 `json:"attempts,omitempty"`
+
+	// State of the target.
+	State TargetState `json:"state"`
+
+	// Machine-readable reason for the state, see the TargetReason constants.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// Human-readable details, e.g. the error which caused a failure.
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
 // DependencyUpdateCheckStatus defines the observed state of DependencyUpdateCheck
 type DependencyUpdateCheckStatus struct {
-	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
-	// Important: Run "make" to regenerate code after modifying this file
+	// The generation of the spec processed by the controller. The status
+	// describes the run of this generation.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Phase of the run of the observed generation.
+	// +optional
+	Phase DependencyUpdateCheckPhase `json:"phase,omitempty"`
+
+	// Time when the controller started processing the observed generation.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// Time when the controller finished processing the observed generation.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// Number of targets the controller considered.
+	// +optional
+	TotalTargets int32 `json:"totalTargets,omitempty"`
+
+	// Number of targets for which a PipelineRun was created.
+	// +optional
+	ScheduledTargets int32 `json:"scheduledTargets,omitempty"`
+
+	// Number of targets which would have been scheduled by a dry run.
+	// +optional
+	PlannedTargets int32 `json:"plannedTargets,omitempty"`
+
+	// Number of targets whose branches haven't been looked up yet.
+	// +optional
+	PendingTargets int32 `json:"pendingTargets,omitempty"`
+
+	// Number of targets waiting for running PipelineRuns to finish.
+	// +optional
+	QueuedTargets int32 `json:"queuedTargets,omitempty"`
+
+	// Number of targets for which creating a PipelineRun failed.
+	// +optional
+	FailedTargets int32 `json:"failedTargets,omitempty"`
+
+	// Number of targets which were skipped.
+	// +optional
+	SkippedTargets int32 `json:"skippedTargets,omitempty"`
+
+	// Number of skipped targets by reason which were dropped from targets, so
+	// the status of checks covering many components stays within the size
+	// limit of objects. They are counted in totalTargets and skippedTargets.
+	// +optional
+	CompactedTargets map[string]int32 `json:"compactedTargets,omitempty"`
+
+	// Every repository+branch target the controller considered, in processing order.
+	// Components and repositories which haven't been processed yet are
+	// recorded as pending targets, so an interrupted run resumes where it stopped.
+	// +optional
+	Targets []TargetStatus `json:"targets,omitempty"`
+
+	// Standard conditions, see the Condition constants.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Targets",type=integer,JSONPath=`.status.totalTargets`
+// +kubebuilder:printcolumn:name="Scheduled",type=integer,JSONPath=`.status.scheduledTargets`
+// +kubebuilder:printcolumn:name="Queued",type=integer,JSONPath=`.status.queuedTargets`
+// +kubebuilder:printcolumn:name="Failed",type=integer,JSONPath=`.status.failedTargets`
+// +kubebuilder:printcolumn:name="Skipped",type=integer,JSONPath=`.status.skippedTargets`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
 // DependencyUpdateCheck is the root CRD that triggers mintmaker to Konflux Components for dependency updates.
 // How the controller uses this CRD:
 // - Only CRs created in the MintMaker namespace (see `MintMakerNamespaceName`) are processed.
 // - When a CR is created, the controller discovers Konflux Components to scan:
 //   - By default: all `appstudio.redhat.com/v1alpha1, Kind=Component` across the cluster
-//   - Or: a filtered subset when `spec.namespaces` is provided
+//   - Or: a filtered subset when `spec.namespaces` or `spec.namespaceSelector` is provided
+//   - Optionally narrowed down by `spec.componentSelector` and `spec.applicationSelector`
+//   - Plus the git repositories listed in `spec.repositories`; if only those are
+//     provided, no Components are discovered
+//   - Minus anything matching `spec.exclude`
 //   - For each unique repository+branch across those Components, the controller generates
 //     one Tekton `PipelineRun` that scans the repository for dependency updates using Renovate.
+//   - With `spec.updateMode: VulnerabilityOnly`, Renovate only proposes updates fixing
+//     known vulnerabilities.
+//   - With `spec.spreadOver`, the PipelineRuns are created over a time window.
+//   - With `spec.dryRun`, the PipelineRuns are only planned and no resources are created.
+//   - The outcome for every repository+branch target is recorded in `status.targets`.
+//
+// Each generation of the spec is processed once, `status.observedGeneration` tells
+// which one the status describes. Editing the spec, or changing `spec.rerunToken`,
+// runs the check again. A run interrupted by a controller restart is resumed without
+// creating a second PipelineRun for targets which were already scheduled.
+//
+// With `spec.cleanupOnDelete`, the check carries the `mintmaker.appstudio.redhat.com/cleanup`
+// finalizer, deleting it cancels and garbage-collects its PipelineRuns.
 //
 // Annotations:
-//   - `mintmaker.appstudio.redhat.com/processed`: set by the controller when the
-//     DependencyUpdateCheck is processed, to avoid reprocessing the same CR.
+//   - `mintmaker.appstudio.redhat.com/processed`: set by older versions of the controller
+//     instead of `status.observedGeneration`. Checks carrying it are not run again.
 type DependencyUpdateCheck struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
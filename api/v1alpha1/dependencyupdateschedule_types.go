@@ -0,0 +1,137 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ConcurrencyPolicy describes how a new DependencyUpdateCheck is handled
+// while a check created by the same schedule is still running.
+// +kubebuilder:validation:Enum=Allow;Forbid;Replace
+type ConcurrencyPolicy string
+
+const (
+	// AllowConcurrent allows checks to run concurrently.
+	AllowConcurrent ConcurrencyPolicy = "Allow"
+	// ForbidConcurrent skips the new check if the previous one hasn't finished yet.
+	ForbidConcurrent ConcurrencyPolicy = "Forbid"
+	// ReplaceConcurrent deletes the running check and creates the new one.
+	ReplaceConcurrent ConcurrencyPolicy = "Replace"
+)
+
+// DependencyUpdateCheckTemplateSpec describes the DependencyUpdateCheck created by a schedule.
+type DependencyUpdateCheckTemplateSpec struct {
+	// Specification of the created DependencyUpdateChecks.
+	// +optional
+	Spec DependencyUpdateCheckSpec `json:"spec,omitempty"`
+}
+
+// DependencyUpdateScheduleSpec defines when DependencyUpdateChecks are created and how they look like.
+type DependencyUpdateScheduleSpec struct {
+	// The schedule in Cron format, see https://en.wikipedia.org/wiki/Cron.
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	Schedule string `json:"schedule"`
+
+	// The time zone name for the schedule, e.g. Europe/Prague.
+	// If omitted, the time zone of the controller is used.
+	// +optional
+	TimeZone *string `json:"timeZone,omitempty"`
+
+	// Deadline in seconds for starting a check which missed its scheduled time.
+	// Runs which are past the deadline are skipped, no check is created for them.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	StartingDeadlineSeconds *int64 `json:"startingDeadlineSeconds,omitempty"`
+
+	// Specifies how to treat concurrent runs of a check. Valid values are:
+	// - "Allow" (default): allows checks to run concurrently;
+	// - "Forbid": skips the next run if the previous one hasn't finished yet;
+	// - "Replace": deletes the running check and replaces it with a new one.
+	// +kubebuilder:default=Allow
+	// +optional
+	ConcurrencyPolicy ConcurrencyPolicy `json:"concurrencyPolicy,omitempty"`
+
+	// Suspends subsequent runs, it does not apply to already started checks.
+	// +optional
+	Suspend *bool `json:"suspend,omitempty"`
+
+	// The number of completed checks to keep.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:default=3
+	// +optional
+	SuccessfulChecksHistoryLimit *int32 `json:"successfulChecksHistoryLimit,omitempty"`
+
+	// The number of failed checks to keep.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:default=1
+	// +optional
+	FailedChecksHistoryLimit *int32 `json:"failedChecksHistoryLimit,omitempty"`
+
+	// The DependencyUpdateCheck created on every run of the schedule.
+	// +required
+	CheckTemplate DependencyUpdateCheckTemplateSpec `json:"checkTemplate"`
+}
+
+// DependencyUpdateScheduleStatus defines the observed state of DependencyUpdateSchedule
+type DependencyUpdateScheduleStatus struct {
+	// The checks created by this schedule which are still running.
+	// +optional
+	// +listType=atomic
+	Active []corev1.ObjectReference `json:"active,omitempty"`
+
+	// Time when a check was last scheduled.
+	// +optional
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+
+	// Time when a check last completed successfully.
+	// +optional
+	LastSuccessfulTime *metav1.Time `json:"lastSuccessfulTime,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Schedule",type=string,JSONPath=`.spec.schedule`
+// +kubebuilder:printcolumn:name="Suspend",type=boolean,JSONPath=`.spec.suspend`
+// +kubebuilder:printcolumn:name="Last Schedule",type=date,JSONPath=`.status.lastScheduleTime`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// DependencyUpdateSchedule creates DependencyUpdateChecks periodically, the same way a
+// batch/v1 CronJob creates Jobs.
+// - Only CRs created in the MintMaker namespace (see `MintMakerNamespaceName`) are processed.
+// - The created checks are owned by the schedule and labeled with
+// `mintmaker.appstudio.redhat.com/dependency-update-schedule`.
+type DependencyUpdateSchedule struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DependencyUpdateScheduleSpec   `json:"spec,omitempty"`
+	Status DependencyUpdateScheduleStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// DependencyUpdateScheduleList contains a list of DependencyUpdateSchedule
+type DependencyUpdateScheduleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DependencyUpdateSchedule `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&DependencyUpdateSchedule{}, &DependencyUpdateScheduleList{})
+}
@@ -19,7 +19,9 @@
 package v1alpha1
 
 import (
-	runtime "k8s.io/apimachinery/pkg/runtime"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -48,7 +50,7 @@ func (in *DependencyUpdateCheck) DeepCopyInto(out *DependencyUpdateCheck) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DependencyUpdateCheck.
@@ -111,6 +113,43 @@ func (in *DependencyUpdateCheckSpec) DeepCopyInto(out *DependencyUpdateCheckSpec
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ComponentSelector != nil {
+		in, out := &in.ComponentSelector, &out.ComponentSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ApplicationSelector != nil {
+		in, out := &in.ApplicationSelector, &out.ApplicationSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Repositories != nil {
+		in, out := &in.Repositories, &out.Repositories
+		*out = make([]RepositorySpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Exclude != nil {
+		in, out := &in.Exclude, &out.Exclude
+		*out = new(ExcludeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RenovateOverrides != nil {
+		in, out := &in.RenovateOverrides, &out.RenovateOverrides
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SpreadOver != nil {
+		in, out := &in.SpreadOver, &out.SpreadOver
+		*out = new(v1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DependencyUpdateCheckSpec.
@@ -126,6 +165,35 @@ func (in *DependencyUpdateCheckSpec) DeepCopy() *DependencyUpdateCheckSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DependencyUpdateCheckStatus) DeepCopyInto(out *DependencyUpdateCheckStatus) {
 	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompactedTargets != nil {
+		in, out := &in.CompactedTargets, &out.CompactedTargets
+		*out = make(map[string]int32, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]TargetStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DependencyUpdateCheckStatus.
@@ -138,6 +206,215 @@ func (in *DependencyUpdateCheckStatus) DeepCopy() *DependencyUpdateCheckStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DependencyUpdateCheckTemplateSpec) DeepCopyInto(out *DependencyUpdateCheckTemplateSpec) {
+	*out = *in
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DependencyUpdateCheckTemplateSpec.
+func (in *DependencyUpdateCheckTemplateSpec) DeepCopy() *DependencyUpdateCheckTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DependencyUpdateCheckTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DependencyUpdateSchedule) DeepCopyInto(out *DependencyUpdateSchedule) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DependencyUpdateSchedule.
+func (in *DependencyUpdateSchedule) DeepCopy() *DependencyUpdateSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(DependencyUpdateSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DependencyUpdateSchedule) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DependencyUpdateScheduleList) DeepCopyInto(out *DependencyUpdateScheduleList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DependencyUpdateSchedule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DependencyUpdateScheduleList.
+func (in *DependencyUpdateScheduleList) DeepCopy() *DependencyUpdateScheduleList {
+	if in == nil {
+		return nil
+	}
+	out := new(DependencyUpdateScheduleList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DependencyUpdateScheduleList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DependencyUpdateScheduleSpec) DeepCopyInto(out *DependencyUpdateScheduleSpec) {
+	*out = *in
+	if in.TimeZone != nil {
+		in, out := &in.TimeZone, &out.TimeZone
+		*out = new(string)
+		**out = **in
+	}
+	if in.StartingDeadlineSeconds != nil {
+		in, out := &in.StartingDeadlineSeconds, &out.StartingDeadlineSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Suspend != nil {
+		in, out := &in.Suspend, &out.Suspend
+		*out = new(bool)
+		**out = **in
+	}
+	if in.SuccessfulChecksHistoryLimit != nil {
+		in, out := &in.SuccessfulChecksHistoryLimit, &out.SuccessfulChecksHistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FailedChecksHistoryLimit != nil {
+		in, out := &in.FailedChecksHistoryLimit, &out.FailedChecksHistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
+	in.CheckTemplate.DeepCopyInto(&out.CheckTemplate)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DependencyUpdateScheduleSpec.
+func (in *DependencyUpdateScheduleSpec) DeepCopy() *DependencyUpdateScheduleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DependencyUpdateScheduleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DependencyUpdateScheduleStatus) DeepCopyInto(out *DependencyUpdateScheduleStatus) {
+	*out = *in
+	if in.Active != nil {
+		in, out := &in.Active, &out.Active
+		*out = make([]corev1.ObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastScheduleTime != nil {
+		in, out := &in.LastScheduleTime, &out.LastScheduleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastSuccessfulTime != nil {
+		in, out := &in.LastSuccessfulTime, &out.LastSuccessfulTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DependencyUpdateScheduleStatus.
+func (in *DependencyUpdateScheduleStatus) DeepCopy() *DependencyUpdateScheduleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DependencyUpdateScheduleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExcludeSpec) DeepCopyInto(out *ExcludeSpec) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Applications != nil {
+		in, out := &in.Applications, &out.Applications
+		*out = make([]ExcludedApplication, len(*in))
+		copy(*out, *in)
+	}
+	if in.Components != nil {
+		in, out := &in.Components, &out.Components
+		*out = make([]ExcludedComponent, len(*in))
+		copy(*out, *in)
+	}
+	if in.Repositories != nil {
+		in, out := &in.Repositories, &out.Repositories
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExcludeSpec.
+func (in *ExcludeSpec) DeepCopy() *ExcludeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ExcludeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExcludedApplication) DeepCopyInto(out *ExcludedApplication) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExcludedApplication.
+func (in *ExcludedApplication) DeepCopy() *ExcludedApplication {
+	if in == nil {
+		return nil
+	}
+	out := new(ExcludedApplication)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExcludedComponent) DeepCopyInto(out *ExcludedComponent) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExcludedComponent.
+func (in *ExcludedComponent) DeepCopy() *ExcludedComponent {
+	if in == nil {
+		return nil
+	}
+	out := new(ExcludedComponent)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NamespaceSpec) DeepCopyInto(out *NamespaceSpec) {
 	*out = *in
@@ -159,3 +436,42 @@ func (in *NamespaceSpec) DeepCopy() *NamespaceSpec {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RepositorySpec) DeepCopyInto(out *RepositorySpec) {
+	*out = *in
+	if in.Branches != nil {
+		in, out := &in.Branches, &out.Branches
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RepositorySpec.
+func (in *RepositorySpec) DeepCopy() *RepositorySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RepositorySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TargetStatus) DeepCopyInto(out *TargetStatus) {
+	*out = *in
+	if in.NotBefore != nil {
+		in, out := &in.NotBefore, &out.NotBefore
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TargetStatus.
+func (in *TargetStatus) DeepCopy() *TargetStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TargetStatus)
+	in.DeepCopyInto(out)
+	return out
+}
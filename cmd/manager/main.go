@@ -33,6 +33,7 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
@@ -43,9 +44,11 @@ import (
 	appstudiov1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
 
 	mmv1alpha1 "github.com/konflux-ci/mintmaker/api/v1alpha1"
+	"github.com/konflux-ci/mintmaker/internal/config"
 	. "github.com/konflux-ci/mintmaker/internal/constant"
 	"github.com/konflux-ci/mintmaker/internal/controller"
 	mintmakermetrics "github.com/konflux-ci/mintmaker/internal/metrics"
+	webhookv1alpha1 "github.com/konflux-ci/mintmaker/internal/webhook/v1alpha1"
 	// +kubebuilder:scaffold:imports
 )
 
@@ -148,6 +151,8 @@ func main() {
 					&corev1.ConfigMap{},
 					&corev1.Pod{},
 					&appstudiov1alpha1.Component{},
+					&appstudiov1alpha1.Application{},
+					&corev1.Namespace{},
 				},
 			},
 		},
@@ -162,6 +167,12 @@ func main() {
 					},
 					Transform: cache.TransformStripManagedFields(),
 				},
+				&mmv1alpha1.DependencyUpdateSchedule{}: {
+					Namespaces: map[string]cache.Config{
+						MintMakerNamespaceName: {},
+					},
+					Transform: cache.TransformStripManagedFields(),
+				},
 				&corev1.Event{}: {
 					Namespaces: map[string]cache.Config{
 						MintMakerNamespaceName: {},
@@ -174,6 +185,12 @@ func main() {
 					},
 					Transform: cache.TransformStripManagedFields(),
 				},
+				&tektonv1.TaskRun{}: {
+					Namespaces: map[string]cache.Config{
+						MintMakerNamespaceName: {},
+					},
+					Transform: cache.TransformStripManagedFields(),
+				},
 			},
 		},
 		Metrics:                metricsServerOptions,
@@ -217,17 +234,35 @@ func main() {
 		setupLog.Info("pprof server disabled", "reason", "ENABLE_PPROFLING env var not set to 'true'")
 	}
 
+	// Finished PipelineRuns release queued targets of DependencyUpdateChecks
+	queueReleases := make(chan event.GenericEvent, 100)
+
 	if err = (&controller.DependencyUpdateCheckReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
+		Client:                    mgr.GetClient(),
+		Scheme:                    mgr.GetScheme(),
+		MaxConcurrentPipelineRuns: config.Get().Scheduling.MaxConcurrentPipelineRuns,
+		TargetBatchSize:           config.Get().Scheduling.TargetBatchSize,
+		TargetWorkers:             config.Get().Scheduling.TargetWorkers,
+		QueueReleases:             queueReleases,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "DependencyUpdateCheck")
 		os.Exit(1)
 	}
 
-	if err = (&controller.PipelineRunReconciler{
+	if err = (&controller.DependencyUpdateScheduleReconciler{
 		Client: mgr.GetClient(),
 		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "DependencyUpdateSchedule")
+		os.Exit(1)
+	}
+
+	if err = (&controller.PipelineRunReconciler{
+		Client:        mgr.GetClient(),
+		Scheme:        mgr.GetScheme(),
+		QueueReleases: queueReleases,
+		MaxAttempts:   config.Get().Retry.MaxAttempts,
+		RetryBackoff:  config.Get().Retry.Backoff,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "PipelineRun")
 		os.Exit(1)
@@ -241,6 +276,17 @@ func main() {
 		os.Exit(1)
 	}
 
+	// The webhook server needs TLS certificates, webhooks are only registered when
+	// the deployment provides them, see config/default/manager_webhook_patch.yaml
+	if os.Getenv("ENABLE_WEBHOOKS") == "true" {
+		if err = webhookv1alpha1.SetupDependencyUpdateCheckWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "DependencyUpdateCheck")
+			os.Exit(1)
+		}
+	} else {
+		setupLog.Info("webhooks disabled", "reason", "ENABLE_WEBHOOKS env var not set to 'true'")
+	}
+
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
 		setupLog.Error(err, "unable to set up health check")
 		os.Exit(1)
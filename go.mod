@@ -11,6 +11,7 @@ require (
 	github.com/konflux-ci/application-api v0.0.0-20260203154344-6f2d131cfcbe
 	github.com/onsi/ginkgo/v2 v2.28.1
 	github.com/onsi/gomega v1.39.1
+	github.com/robfig/cron/v3 v3.0.1
 	github.com/tektoncd/pipeline v1.9.0
 	gitlab.com/gitlab-org/api/client-go v1.26.0
 	golang.org/x/oauth2 v0.34.0
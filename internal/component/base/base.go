@@ -24,6 +24,7 @@ import (
 	"sync"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/strings/slices"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -32,6 +33,14 @@ import (
 	bslices "github.com/konflux-ci/mintmaker/internal/slices"
 )
 
+const (
+	// CredentialSourceGitHubApp means the token is generated for an
+	// installation of the GitHub App
+	CredentialSourceGitHubApp = "GitHubApp"
+	// CredentialSourceScmSecret means the token is read from an scm secret
+	CredentialSourceScmSecret = "ScmSecret"
+)
+
 var (
 	renovateBaseConfig      map[string]interface{}
 	renovateBaseConfigMutex sync.RWMutex
@@ -49,6 +58,8 @@ type BaseComponent struct {
 	Repository    string
 	Versions      []string
 	OldCRDVersion bool
+	// Raw JSON deep-merged on top of the Renovate base config
+	RenovateOverrides []byte
 }
 
 func (c *BaseComponent) GetName() string {
@@ -79,6 +90,16 @@ func (c *BaseComponent) GetRepository() string {
 	return c.Repository
 }
 
+// GetCredentialSource returns the source of the token of the repository, an
+// scm secret unless the platform implements other sources
+func (c *BaseComponent) GetCredentialSource() string {
+	return CredentialSourceScmSecret
+}
+
+func (c *BaseComponent) SetRenovateOverrides(overrides []byte) {
+	c.RenovateOverrides = overrides
+}
+
 type HostRule map[string]string
 
 func (c *BaseComponent) GetHostRules(ctx context.Context, registrySecret *corev1.Secret) ([]HostRule, error) {
@@ -128,7 +149,27 @@ func (c *BaseComponent) GetHostRules(ctx context.Context, registrySecret *corev1
 	return hostRules, nil
 }
 
+// GetRenovateBaseConfig returns the Renovate config shared by all components,
+// with the overrides of the component merged on top of it. The returned map is
+// a copy which can be modified by the caller.
 func (c *BaseComponent) GetRenovateBaseConfig(ctx context.Context, client client.Client) (map[string]interface{}, error) {
+	config, err := getCachedRenovateBaseConfig(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	config = runtime.DeepCopyJSON(config)
+
+	if len(c.RenovateOverrides) == 0 {
+		return config, nil
+	}
+	var overrides map[string]interface{}
+	if err := json.Unmarshal(c.RenovateOverrides, &overrides); err != nil {
+		return nil, fmt.Errorf("error unmarshaling Renovate config overrides: %v", err)
+	}
+	return MergeRenovateConfig(config, overrides), nil
+}
+
+func getCachedRenovateBaseConfig(ctx context.Context, client client.Client) (map[string]interface{}, error) {
 	renovateBaseConfigMutex.RLock()
 	if renovateBaseConfig != nil {
 		defer renovateBaseConfigMutex.RUnlock()
@@ -161,6 +202,49 @@ func (c *BaseComponent) GetRenovateBaseConfig(ctx context.Context, client client
 	return config, nil
 }
 
+// VulnerabilityOnlyConfig returns the Renovate config which limits the updates
+// to the ones fixing known vulnerabilities. All package updates are disabled,
+// vulnerability alerts, including the ones from the OSV database, bypass it.
+func VulnerabilityOnlyConfig() map[string]interface{} {
+	return map[string]interface{}{
+		"osvVulnerabilityAlerts": true,
+		"vulnerabilityAlerts": map[string]interface{}{
+			"enabled": true,
+		},
+		"packageRules": []interface{}{
+			map[string]interface{}{
+				"matchPackageNames": []interface{}{"*"},
+				"enabled":           false,
+			},
+		},
+	}
+}
+
+// MergeRenovateConfig deep-merges overrides into config and returns config.
+// Objects are merged key by key, `packageRules` are appended to the existing
+// rules, the same way Renovate merges them, and any other value is replaced.
+func MergeRenovateConfig(config, overrides map[string]interface{}) map[string]interface{} {
+	if config == nil {
+		config = map[string]interface{}{}
+	}
+	for key, value := range overrides {
+		switch override := value.(type) {
+		case map[string]interface{}:
+			if existing, ok := config[key].(map[string]interface{}); ok {
+				config[key] = MergeRenovateConfig(existing, override)
+				continue
+			}
+		case []interface{}:
+			if existing, ok := config[key].([]interface{}); ok && key == "packageRules" {
+				config[key] = append(existing, override...)
+				continue
+			}
+		}
+		config[key] = value
+	}
+	return config
+}
+
 func getActivationKeyFromSecret(secret *corev1.Secret) (string, string, error) {
 
 	if secret == nil {
@@ -267,3 +351,87 @@ func (c *BaseComponent) GetRPMActivationKey(ctx context.Context, k8sClient clien
 	return getActivationKeyFromSecret(&secrets[bestIndex])
 
 }
+
+// LookupSecret returns the scm secret of the namespace of the component which
+// holds the credentials for its repository. Secrets annotated with the exact
+// repository are preferred over wildcard matches, then over host-only secrets.
+func (c *BaseComponent) LookupSecret(ctx context.Context, k8sClient client.Client) (*corev1.Secret, error) {
+
+	secretList := &corev1.SecretList{}
+	opts := client.ListOption(&client.MatchingLabels{
+		"appstudio.redhat.com/credentials": "scm",
+		"appstudio.redhat.com/scm.host":    c.Host,
+	})
+
+	// find secrets that have the following labels:
+	//	- "appstudio.redhat.com/credentials": "scm"
+	//	- "appstudio.redhat.com/scm.host": <name of component host>
+	if err := k8sClient.List(ctx, secretList, client.InNamespace(c.Namespace), opts); err != nil {
+		return nil, fmt.Errorf("failed to list scm secrets in namespace %s: %w", c.Namespace, err)
+	}
+
+	// filtering to get BasicAuth secrets and data is not empty
+	secrets := bslices.Filter(secretList.Items, func(secret corev1.Secret) bool {
+		return secret.Type == corev1.SecretTypeBasicAuth && len(secret.Data) > 0
+	})
+	if len(secrets) == 0 {
+		return nil, fmt.Errorf("no secrets available for git host %s", c.Host)
+	}
+
+	// secrets only match with component's host
+	var hostOnlySecrets []corev1.Secret
+	// map of secret index and its best path intersections count, i.e. the count of path parts matched,
+	var potentialMatches = make(map[int]int, len(secrets))
+
+	for index, secret := range secrets {
+		repositoryAnnotation, exists := secret.Annotations["appstudio.redhat.com/scm.repository"]
+		if !exists || repositoryAnnotation == "" {
+			hostOnlySecrets = append(hostOnlySecrets, secret)
+			continue
+		}
+
+		secretRepositories := strings.Split(repositoryAnnotation, ",")
+		// trim possible prefix or suffix "/"
+		for i, repository := range secretRepositories {
+			secretRepositories[i] = strings.TrimPrefix(strings.TrimSuffix(repository, "/"), "/")
+		}
+
+		// this secret matches exactly the component's repository name
+		if slices.Contains(secretRepositories, c.Repository) {
+			return &secret, nil
+		}
+
+		// no direct match, check for wildcard match, i.e. org/repo/* matches org/repo/foo, org/repo/bar, etc.
+		componentRepoParts := strings.Split(c.Repository, "/")
+
+		// find wildcard repositories
+		wildcardRepos := slices.Filter(nil, secretRepositories, func(s string) bool { return strings.HasSuffix(s, "*") })
+
+		for _, repo := range wildcardRepos {
+			i := bslices.Intersection(componentRepoParts, strings.Split(strings.TrimSuffix(repo, "*"), "/"))
+			if i > 0 && potentialMatches[index] < i {
+				// add whole secret index to potential matches
+				potentialMatches[index] = i
+			}
+		}
+	}
+
+	if len(potentialMatches) == 0 {
+		if len(hostOnlySecrets) == 0 {
+			// no potential matches, no host matches, nothing to return
+			return nil, fmt.Errorf("no secrets available for component")
+		}
+		// no potential matches, but we have host match secrets, return the first one
+		return &hostOnlySecrets[0], nil
+	}
+
+	// some potential matches exist, find the best one
+	var bestIndex, bestCount int
+	for i, count := range potentialMatches {
+		if count > bestCount {
+			bestCount = count
+			bestIndex = i
+		}
+	}
+	return &secrets[bestIndex], nil
+}
@@ -0,0 +1,123 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package base
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func unmarshalConfig(t *testing.T, data string) map[string]interface{} {
+	t.Helper()
+	var config map[string]interface{}
+	if err := json.Unmarshal([]byte(data), &config); err != nil {
+		t.Fatalf("failed to unmarshal %s: %v", data, err)
+	}
+	return config
+}
+
+func TestMergeRenovateConfig(t *testing.T) {
+	tests := []struct {
+		name      string
+		config    string
+		overrides string
+		expected  string
+	}{
+		{
+			name:      "should add new keys",
+			config:    `{"extends": ["config:recommended"]}`,
+			overrides: `{"prConcurrentLimit": 20}`,
+			expected:  `{"extends": ["config:recommended"], "prConcurrentLimit": 20}`,
+		},
+		{
+			name:      "should replace scalar values",
+			config:    `{"prConcurrentLimit": 5, "dependencyDashboard": true}`,
+			overrides: `{"prConcurrentLimit": 20}`,
+			expected:  `{"prConcurrentLimit": 20, "dependencyDashboard": true}`,
+		},
+		{
+			name:      "should merge objects recursively",
+			config:    `{"tekton": {"enabled": true, "schedule": ["at any time"]}}`,
+			overrides: `{"tekton": {"enabled": false}}`,
+			expected:  `{"tekton": {"enabled": false, "schedule": ["at any time"]}}`,
+		},
+		{
+			name:      "should replace arrays",
+			config:    `{"enabledManagers": ["tekton", "dockerfile"]}`,
+			overrides: `{"enabledManagers": ["tekton"]}`,
+			expected:  `{"enabledManagers": ["tekton"]}`,
+		},
+		{
+			name:      "should append package rules",
+			config:    `{"packageRules": [{"matchManagers": ["npm"], "enabled": false}]}`,
+			overrides: `{"packageRules": [{"matchManagers": ["tekton"], "automerge": true}]}`,
+			expected:  `{"packageRules": [{"matchManagers": ["npm"], "enabled": false}, {"matchManagers": ["tekton"], "automerge": true}]}`,
+		},
+		{
+			name:      "should replace a value of a different type",
+			config:    `{"schedule": "at any time"}`,
+			overrides: `{"schedule": ["before 5am"]}`,
+			expected:  `{"schedule": ["before 5am"]}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := MergeRenovateConfig(unmarshalConfig(t, tt.config), unmarshalConfig(t, tt.overrides))
+			expected := unmarshalConfig(t, tt.expected)
+			if !reflect.DeepEqual(got, expected) {
+				t.Errorf("MergeRenovateConfig() = %v, expected %v", got, expected)
+			}
+		})
+	}
+}
+
+func TestGetRenovateBaseConfigWithOverrides(t *testing.T) {
+	renovateBaseConfigMutex.Lock()
+	renovateBaseConfig = unmarshalConfig(t, `{"prConcurrentLimit": 5, "packageRules": [{"enabled": false}]}`)
+	renovateBaseConfigMutex.Unlock()
+	defer func() {
+		renovateBaseConfigMutex.Lock()
+		renovateBaseConfig = nil
+		renovateBaseConfigMutex.Unlock()
+	}()
+
+	c := &BaseComponent{}
+	c.SetRenovateOverrides([]byte(`{"prConcurrentLimit": 20, "packageRules": [{"automerge": true}]}`))
+	config, err := c.GetRenovateBaseConfig(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("GetRenovateBaseConfig() returned an error: %v", err)
+	}
+	expected := unmarshalConfig(t, `{"prConcurrentLimit": 20, "packageRules": [{"enabled": false}, {"automerge": true}]}`)
+	if !reflect.DeepEqual(config, expected) {
+		t.Errorf("GetRenovateBaseConfig() = %v, expected %v", config, expected)
+	}
+
+	// The cached config must not be modified by the overrides of a component
+	config, err = (&BaseComponent{}).GetRenovateBaseConfig(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("GetRenovateBaseConfig() returned an error: %v", err)
+	}
+	expected = unmarshalConfig(t, `{"prConcurrentLimit": 5, "packageRules": [{"enabled": false}]}`)
+	if !reflect.DeepEqual(config, expected) {
+		t.Errorf("GetRenovateBaseConfig() = %v, expected %v", config, expected)
+	}
+
+	c.SetRenovateOverrides([]byte(`not json`))
+	if _, err := c.GetRenovateBaseConfig(context.Background(), nil); err == nil {
+		t.Errorf("GetRenovateBaseConfig() expected an error for invalid overrides")
+	}
+}
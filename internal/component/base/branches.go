@@ -0,0 +1,151 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package base
+
+import (
+	"cmp"
+	"fmt"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// IsBranchPattern returns true if the version is a pattern matching branches
+// rather than a single branch: a regular expression enclosed in slashes, as in
+// Renovate's baseBranches, or a glob with *, ? or [].
+func IsBranchPattern(version string) bool {
+	return isRegexPattern(version) || strings.ContainsAny(version, "*?[")
+}
+
+func isRegexPattern(version string) bool {
+	return len(version) > 2 && strings.HasPrefix(version, "/") && strings.HasSuffix(version, "/")
+}
+
+// MatchBranch returns true if the branch matches the pattern. Invalid patterns
+// never match.
+func MatchBranch(pattern, branch string) bool {
+	if isRegexPattern(pattern) {
+		re, err := regexp.Compile(pattern[1 : len(pattern)-1])
+		if err != nil {
+			return false
+		}
+		return re.MatchString(branch)
+	}
+	matched, _ := path.Match(pattern, branch)
+	return matched
+}
+
+// ResolveBranches returns the existing branches of the versions, in the order
+// of the versions. Single branches are looked up by branchExists, whose errors
+// are returned.
+//
+// Patterns are matched against the branches of the repository, which are
+// listed once by listBranches. The matches of a pattern are ordered from the
+// highest version down, comparing the numbers in branch names by value, so
+// release-10 comes before release-9.
+//
+// At most maxMatches branches are taken per pattern, which are usually the
+// latest releases. 0 means no limit.
+func ResolveBranches(versions []string, branchExists func(string) (bool, error), listBranches func() ([]string, error), maxMatches int) ([]string, error) {
+	var branches []string
+	var repositoryBranches []string
+	listed := false
+
+	for _, version := range versions {
+		if !IsBranchPattern(version) {
+			if slices.Contains(branches, version) {
+				continue
+			}
+			exists, err := branchExists(version)
+			if err != nil {
+				return nil, fmt.Errorf("failed to look up branch %s: %w", version, err)
+			}
+			if exists {
+				branches = append(branches, version)
+			}
+			continue
+		}
+
+		if !listed {
+			var err error
+			repositoryBranches, err = listBranches()
+			if err != nil {
+				return nil, fmt.Errorf("failed to list branches for pattern %s: %w", version, err)
+			}
+			listed = true
+		}
+
+		matches := []string{}
+		for _, branch := range repositoryBranches {
+			if MatchBranch(version, branch) {
+				matches = append(matches, branch)
+			}
+		}
+		slices.SortFunc(matches, func(a, b string) int {
+			return compareVersions(b, a)
+		})
+		if maxMatches > 0 && len(matches) > maxMatches {
+			matches = matches[:maxMatches]
+		}
+		for _, branch := range matches {
+			if !slices.Contains(branches, branch) {
+				branches = append(branches, branch)
+			}
+		}
+	}
+	return branches, nil
+}
+
+// compareVersions compares the names like strings, except that runs of digits
+// are compared by their numeric value, e.g. release-9 sorts before release-10
+func compareVersions(a, b string) int {
+	for a != "" && b != "" {
+		aDigits, bDigits := isDigit(a[0]), isDigit(b[0])
+		if aDigits != bDigits {
+			return strings.Compare(a, b)
+		}
+		aRun, aRest := splitRun(a, aDigits)
+		bRun, bRest := splitRun(b, bDigits)
+		if aDigits {
+			// Compare the numbers by length first, ignoring leading zeros
+			aNumber, bNumber := strings.TrimLeft(aRun, "0"), strings.TrimLeft(bRun, "0")
+			if c := cmp.Compare(len(aNumber), len(bNumber)); c != 0 {
+				return c
+			}
+			if c := strings.Compare(aNumber, bNumber); c != 0 {
+				return c
+			}
+		}
+		if c := strings.Compare(aRun, bRun); c != 0 {
+			return c
+		}
+		a, b = aRest, bRest
+	}
+	return cmp.Compare(len(a), len(b))
+}
+
+// splitRun splits the leading run of digits or non-digits off the string
+func splitRun(s string, digits bool) (string, string) {
+	i := 0
+	for i < len(s) && isDigit(s[i]) == digits {
+		i++
+	}
+	return s[:i], s[i:]
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
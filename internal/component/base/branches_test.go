@@ -0,0 +1,157 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package base
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestMatchBranch(t *testing.T) {
+	tests := []struct {
+		pattern string
+		branch  string
+		matched bool
+	}{
+		{"release-*", "release-1.0", true},
+		{"release-*", "main", false},
+		{"release-?", "release-1", true},
+		{"release-?", "release-10", false},
+		{"release/*", "release/1.0", true},
+		{"/^release-\\d+$/", "release-12", true},
+		{"/^release-\\d+$/", "release-12-rc", false},
+		{"/[/", "[", false},
+		{"[", "[", false},
+	}
+	for _, tt := range tests {
+		if got := MatchBranch(tt.pattern, tt.branch); got != tt.matched {
+			t.Errorf("MatchBranch(%q, %q) = %v, want %v", tt.pattern, tt.branch, got, tt.matched)
+		}
+	}
+}
+
+func TestResolveBranches(t *testing.T) {
+	repositoryBranches := []string{"main", "release-1", "release-2", "release-3", "release-10", "feature"}
+	branchExists := func(branch string) (bool, error) {
+		return slices.Contains(repositoryBranches, branch), nil
+	}
+
+	tests := []struct {
+		name       string
+		versions   []string
+		maxMatches int
+		expected   []string
+	}{
+		{
+			name:     "exact versions which are branches",
+			versions: []string{"main", "v1.0", "feature"},
+			expected: []string{"main", "feature"},
+		},
+		{
+			name:     "glob pattern",
+			versions: []string{"main", "release-*"},
+			expected: []string{"main", "release-10", "release-3", "release-2", "release-1"},
+		},
+		{
+			name:     "regular expression",
+			versions: []string{"/^(main|feature)$/"},
+			expected: []string{"main", "feature"},
+		},
+		{
+			name:       "capped pattern keeps the highest versions",
+			versions:   []string{"release-*"},
+			maxMatches: 2,
+			expected:   []string{"release-10", "release-3"},
+		},
+		{
+			name:     "branches matched twice are kept once",
+			versions: []string{"release-2", "release-*"},
+			expected: []string{"release-2", "release-10", "release-3", "release-1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			listed := 0
+			listBranches := func() ([]string, error) {
+				listed++
+				return repositoryBranches, nil
+			}
+			branches, err := ResolveBranches(tt.versions, branchExists, listBranches, tt.maxMatches)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(branches, tt.expected) {
+				t.Errorf("got %v, want %v", branches, tt.expected)
+			}
+			if listed > 1 {
+				t.Errorf("branches were listed %d times", listed)
+			}
+		})
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"release-9", "release-10", -1},
+		{"release-1.10", "release-1.9", 1},
+		{"release-1.2", "release-1.2", 0},
+		{"release-01", "release-1", -1},
+		{"release-1", "release-1-rc", -1},
+		{"main", "release-1", -1},
+		{"v2", "v10", -1},
+	}
+	for _, tt := range tests {
+		if got := compareVersions(tt.a, tt.b); got != tt.expected {
+			t.Errorf("compareVersions(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.expected)
+		}
+		if got := compareVersions(tt.b, tt.a); got != -tt.expected {
+			t.Errorf("compareVersions(%q, %q) = %d, want %d", tt.b, tt.a, got, -tt.expected)
+		}
+	}
+}
+
+func TestResolveBranchesListOnlyForPatterns(t *testing.T) {
+	listBranches := func() ([]string, error) {
+		return nil, errors.New("listing failed")
+	}
+	branches, err := ResolveBranches([]string{"main"}, func(string) (bool, error) { return true, nil }, listBranches, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(branches, []string{"main"}) {
+		t.Errorf("got %v, want [main]", branches)
+	}
+
+	if _, err := ResolveBranches([]string{"release-*"}, func(string) (bool, error) { return true, nil }, listBranches, 0); err == nil {
+		t.Error("expected the listing error for a pattern")
+	}
+}
+
+func TestResolveBranchesLookupError(t *testing.T) {
+	branchExists := func(string) (bool, error) {
+		return false, errors.New("lookup failed")
+	}
+	listBranches := func() ([]string, error) {
+		return nil, nil
+	}
+	if _, err := ResolveBranches([]string{"main"}, branchExists, listBranches, 0); err == nil {
+		t.Error("expected the lookup error for a branch")
+	}
+}
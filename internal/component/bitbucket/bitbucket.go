@@ -0,0 +1,310 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package bitbucket implements the git component of repositories hosted on
+// Bitbucket Server and Bitbucket Data Center. Bitbucket Cloud (bitbucket.org)
+// has a different API and isn't supported.
+package bitbucket
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appstudiov1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
+
+	"github.com/konflux-ci/mintmaker/internal/component/base"
+	"github.com/konflux-ci/mintmaker/internal/config"
+	"github.com/konflux-ci/mintmaker/internal/utils"
+)
+
+const (
+	// renovatePlatform is the Renovate platform of Bitbucket Server and Data Center
+	renovatePlatform = "bitbucket-server"
+	// pageLimit is the number of branches requested per page
+	pageLimit = 100
+	// requestTimeout bounds every request to the Bitbucket REST API
+	requestTimeout = 30 * time.Second
+)
+
+type Component struct {
+	base.BaseComponent
+	client client.Client
+	ctx    context.Context
+	// baseURL is the scheme and host (with port) of the Bitbucket instance
+	baseURL string
+	// project and slug identify the repository in the REST API
+	project string
+	slug    string
+}
+
+// branch is a branch in Bitbucket REST API responses
+type branch struct {
+	DisplayID string `json:"displayId"`
+}
+
+// branchPage is a page of branches in Bitbucket REST API responses
+type branchPage struct {
+	Values        []branch `json:"values"`
+	IsLastPage    bool     `json:"isLastPage"`
+	NextPageStart int      `json:"nextPageStart"`
+}
+
+func NewComponent(ctx context.Context, comp *appstudiov1alpha1.Component, client client.Client, giturl string, versions []string, oldCRDVersion bool) (*Component, error) {
+	host, err := utils.GetGitHost(giturl)
+	if err != nil {
+		return nil, err
+	}
+	if host == "bitbucket.org" {
+		return nil, fmt.Errorf("unsupported Bitbucket Cloud repository %s", giturl)
+	}
+	path, err := utils.GetGitPath(giturl)
+	if err != nil {
+		return nil, err
+	}
+	project, slug, err := parseRepositoryPath(path)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Bitbucket repository %s: %w", giturl, err)
+	}
+
+	// SSH URLs don't carry the scheme and port of the web server
+	baseURL := "https://" + host
+	if u, err := url.Parse(giturl); err == nil && (u.Scheme == "http" || u.Scheme == "https") {
+		baseURL = u.Scheme + "://" + u.Host
+	}
+	if endpoint := config.Get().APIEndpoint(host); endpoint != "" {
+		baseURL = strings.TrimSuffix(endpoint, "/")
+	}
+
+	return &Component{
+		BaseComponent: base.BaseComponent{
+			Name:          comp.Name,
+			Namespace:     comp.Namespace,
+			Application:   comp.Spec.Application,
+			Platform:      "bitbucket",
+			Host:          host,
+			GitURL:        giturl,
+			Repository:    project + "/" + slug,
+			Versions:      versions,
+			OldCRDVersion: oldCRDVersion,
+		},
+		client:  client,
+		ctx:     ctx,
+		baseURL: baseURL,
+		project: project,
+		slug:    slug,
+	}, nil
+}
+
+// GetRepository returns the repository of a Bitbucket git URL as PROJECT/repo,
+// whatever the shape of the URL
+func GetRepository(giturl string) (string, error) {
+	path, err := utils.GetGitPath(giturl)
+	if err != nil {
+		return "", err
+	}
+	project, slug, err := parseRepositoryPath(path)
+	if err != nil {
+		return "", err
+	}
+	return project + "/" + slug, nil
+}
+
+// parseRepositoryPath returns the project key and repository slug of the path
+// of a clone URL (scm/PROJECT/repo), an SSH URL (PROJECT/repo) or a browse URL
+// (projects/PROJECT/repos/repo/browse)
+func parseRepositoryPath(path string) (string, string, error) {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	switch {
+	case len(parts) >= 4 && parts[0] == "projects" && parts[2] == "repos":
+		return parts[1], parts[3], nil
+	case len(parts) == 3 && parts[0] == "scm":
+		return parts[1], parts[2], nil
+	case len(parts) == 2:
+		return parts[0], parts[1], nil
+	}
+	return "", "", fmt.Errorf("unexpected repository path %s", path)
+}
+
+func (c *Component) GetBranches() ([]string, error) {
+	if len(c.Versions) == 0 && c.OldCRDVersion {
+		defaultBranch, err := c.getDefaultBranch()
+		if err != nil {
+			return []string{}, fmt.Errorf("component does not have a branch specified and failed to get default branch: %w", err)
+		}
+		return []string{defaultBranch}, nil
+	}
+
+	token, err := c.GetToken()
+	if err != nil {
+		return []string{}, fmt.Errorf("failed to get Bitbucket token: %w", err)
+	}
+
+	branchExists := func(name string) (bool, error) {
+		// filterText matches branches containing the text, look for the exact name
+		names, err := c.listBranches(token, name)
+		if err != nil {
+			return false, err
+		}
+		return slices.Contains(names, name), nil
+	}
+	listBranches := func() ([]string, error) {
+		return c.listBranches(token, "")
+	}
+	branches, err := base.ResolveBranches(c.Versions, branchExists, listBranches, config.Get().Branches.MaxPatternMatches)
+	if err != nil {
+		return []string{}, err
+	}
+
+	if len(branches) == 0 {
+		return []string{}, fmt.Errorf("no versions found or all versions are tags (not branches)")
+	}
+
+	return branches, nil
+}
+
+// listBranches returns the names of the branches of the repository containing
+// the filter text, or of all branches if the filter is empty
+func (c *Component) listBranches(token, filter string) ([]string, error) {
+	var names []string
+	start := 0
+	for {
+		query := url.Values{}
+		query.Set("start", strconv.Itoa(start))
+		query.Set("limit", strconv.Itoa(pageLimit))
+		if filter != "" {
+			query.Set("filterText", filter)
+		}
+		page := &branchPage{}
+		if err := c.get(token, "branches", query, page); err != nil {
+			return nil, err
+		}
+		for _, branch := range page.Values {
+			names = append(names, branch.DisplayID)
+		}
+		if page.IsLastPage || len(page.Values) == 0 {
+			return names, nil
+		}
+		start = page.NextPageStart
+	}
+}
+
+func (c *Component) GetToken() (string, error) {
+
+	secret, err := c.LookupSecret(c.ctx, c.client)
+	if err != nil {
+		return "", err
+	}
+	return string(secret.Data[corev1.BasicAuthPasswordKey]), nil
+}
+
+// GetAPIEndpoint returns the URL of the Bitbucket instance, Renovate appends
+// the path of the REST API itself
+func (c *Component) GetAPIEndpoint() string {
+	return c.baseURL + "/"
+}
+
+func (c *Component) getDefaultBranch() (string, error) {
+	token, err := c.GetToken()
+	if err != nil {
+		return "", fmt.Errorf("failed to get Bitbucket token: %w", err)
+	}
+
+	defaultBranch := &branch{}
+	if err := c.get(token, "branches/default", nil, defaultBranch); err != nil {
+		return "", err
+	}
+	if defaultBranch.DisplayID == "" {
+		return "", fmt.Errorf("default branch is empty in Bitbucket API response")
+	}
+
+	return defaultBranch.DisplayID, nil
+}
+
+// get requests the resource of the repository at the given path of the REST
+// API and decodes the JSON response into out
+func (c *Component) get(token, resource string, query url.Values, out interface{}) error {
+	endpoint := fmt.Sprintf("%s/rest/api/1.0/projects/%s/repos/%s/%s",
+		c.baseURL, url.PathEscape(c.project), url.PathEscape(c.slug), resource)
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	ctx, cancel := context.WithTimeout(c.ctx, requestTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to request Bitbucket API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("request for %s of %s to Bitbucket API failed: %s", resource, c.Repository, resp.Status)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode Bitbucket API response: %w", err)
+	}
+	return nil
+}
+
+func (c *Component) GetRenovateConfig(registrySecret *corev1.Secret, currentBranch string) (string, error) {
+	baseConfig, err := c.GetRenovateBaseConfig(c.ctx, c.client)
+	if err != nil {
+		return "", err
+	}
+
+	// Add component-specific hostRules if registrySecret is provided
+	if registrySecret != nil {
+		hostRules, err := c.GetHostRules(c.ctx, registrySecret)
+		if err == nil && len(hostRules) > 0 {
+			baseConfig["hostRules"] = hostRules
+		}
+	}
+
+	baseConfig["platform"] = renovatePlatform
+	baseConfig["endpoint"] = c.GetAPIEndpoint()
+	// HTTP access tokens are used as bearer tokens, so like for gitlab no
+	// username or gitAuthor is needed
+	baseConfig["username"] = ""
+	baseConfig["gitAuthor"] = ""
+
+	repo := map[string]interface{}{
+		"baseBranchPatterns": []string{currentBranch},
+		"repository":         c.Repository,
+	}
+	baseConfig["repositories"] = []interface{}{repo}
+
+	updatedConfig, err := json.MarshalIndent(baseConfig, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("error marshaling updated Renovate config: %v", err)
+	}
+
+	return string(updatedConfig), nil
+}
@@ -0,0 +1,232 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bitbucket
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	appstudiov1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
+)
+
+const (
+	testToken     = "bitbucket-token"
+	testNamespace = "testnamespace"
+)
+
+// newFakeBitbucket returns a fake of the Bitbucket REST API serving the branches
+// of the repository PROJ/repo, two per page
+func newFakeBitbucket(branches []string, defaultBranch string) *httptest.Server {
+	const repoPath = "/rest/api/1.0/projects/PROJ/repos/repo/"
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+testToken {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case repoPath + "branches/default":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"displayId": defaultBranch})
+		case repoPath + "branches":
+			var matching []map[string]interface{}
+			for _, branch := range branches {
+				if strings.Contains(branch, r.URL.Query().Get("filterText")) {
+					matching = append(matching, map[string]interface{}{"displayId": branch})
+				}
+			}
+			start, _ := strconv.Atoi(r.URL.Query().Get("start"))
+			end := min(start+2, len(matching))
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"values":        matching[start:end],
+				"isLastPage":    end == len(matching),
+				"nextPageStart": end,
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func newScmSecret(name, host, repositories string) *corev1.Secret {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: testNamespace,
+			Labels: map[string]string{
+				"appstudio.redhat.com/credentials": "scm",
+				"appstudio.redhat.com/scm.host":    host,
+			},
+		},
+		Type: corev1.SecretTypeBasicAuth,
+		Data: map[string][]byte{
+			corev1.BasicAuthUsernameKey: []byte("user"),
+			corev1.BasicAuthPasswordKey: []byte(name + "-password"),
+		},
+	}
+	if repositories != "" {
+		secret.Annotations = map[string]string{"appstudio.redhat.com/scm.repository": repositories}
+	}
+	return secret
+}
+
+var _ = Describe("Bitbucket component", func() {
+	var (
+		server    *httptest.Server
+		k8sClient client.Client
+		gitURL    string
+	)
+
+	newComponent := func(giturl string, versions []string, oldCRDVersion bool) *Component {
+		comp := &appstudiov1alpha1.Component{
+			ObjectMeta: metav1.ObjectMeta{Name: "testcomp", Namespace: testNamespace},
+			Spec:       appstudiov1alpha1.ComponentSpec{Application: "testapp"},
+		}
+		c, err := NewComponent(context.Background(), comp, k8sClient, giturl, versions, oldCRDVersion)
+		Expect(err).NotTo(HaveOccurred())
+		return c
+	}
+
+	BeforeEach(func() {
+		server = newFakeBitbucket([]string{"main", "release-1", "release-2", "release-3", "feature"}, "main")
+		gitURL = server.URL + "/scm/PROJ/repo.git"
+
+		scheme := runtime.NewScheme()
+		Expect(corev1.AddToScheme(scheme)).To(Succeed())
+		secret := newScmSecret("bitbucket", "127.0.0.1", "")
+		secret.Data[corev1.BasicAuthPasswordKey] = []byte(testToken)
+		k8sClient = fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			secret,
+			&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "mintmaker", Name: "renovate-config"},
+				Data: map[string]string{
+					"renovate.json":    `{"extends": ["config:recommended"]}`,
+					"self_hosted.json": `{"onboarding": false}`,
+				},
+			},
+		).Build()
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Context("When parsing the repository URL", func() {
+		It("should accept clone, SSH and browse URLs", func() {
+			for _, giturl := range []string{
+				"https://bitbucket.example.com/scm/PROJ/repo.git",
+				"git@bitbucket.example.com:PROJ/repo.git",
+				"https://bitbucket.example.com/projects/PROJ/repos/repo/browse",
+			} {
+				c := newComponent(giturl, nil, false)
+				Expect(c.GetRepository()).To(Equal("PROJ/repo"), giturl)
+				Expect(c.GetHost()).To(Equal("bitbucket.example.com"), giturl)
+				Expect(c.GetPlatform()).To(Equal("bitbucket"), giturl)
+				Expect(c.GetAPIEndpoint()).To(Equal("https://bitbucket.example.com/"), giturl)
+				Expect(GetRepository(giturl)).To(Equal("PROJ/repo"), giturl)
+			}
+		})
+
+		It("should reject Bitbucket Cloud repositories", func() {
+			_, err := NewComponent(context.Background(), &appstudiov1alpha1.Component{}, k8sClient,
+				"https://bitbucket.org/team/repo.git", nil, false)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("When looking up the token", func() {
+		It("should prefer the secret of the repository over the host secret", func() {
+			Expect(k8sClient.Create(context.Background(), newScmSecret("other", "127.0.0.1", "OTHER/repo"))).To(Succeed())
+			Expect(k8sClient.Create(context.Background(), newScmSecret("repo", "127.0.0.1", "PROJ/repo"))).To(Succeed())
+
+			token, err := newComponent(gitURL, nil, false).GetToken()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(token).To(Equal("repo-password"))
+		})
+
+		It("should fail without a secret for the host", func() {
+			_, err := newComponent("https://bitbucket.example.com/scm/PROJ/repo.git", nil, false).GetToken()
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("When getting the branches", func() {
+		It("should return the versions which are branches", func() {
+			branches, err := newComponent(gitURL, []string{"main", "v1.0", "feature"}, false).GetBranches()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(branches).To(Equal([]string{"main", "feature"}))
+		})
+
+		It("should resolve patterns over all pages of branches", func() {
+			branches, err := newComponent(gitURL, []string{"release-*"}, false).GetBranches()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(branches).To(Equal([]string{"release-3", "release-2", "release-1"}))
+		})
+
+		It("should return the default branch for the old component model", func() {
+			branches, err := newComponent(gitURL, nil, true).GetBranches()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(branches).To(Equal([]string{"main"}))
+		})
+
+		It("should fail if no version is a branch", func() {
+			_, err := newComponent(gitURL, []string{"v1.0"}, false).GetBranches()
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should fail if the token is rejected", func() {
+			secret := &corev1.Secret{}
+			Expect(k8sClient.Get(context.Background(), client.ObjectKey{Namespace: testNamespace, Name: "bitbucket"}, secret)).To(Succeed())
+			secret.Data[corev1.BasicAuthPasswordKey] = []byte("revoked")
+			Expect(k8sClient.Update(context.Background(), secret)).To(Succeed())
+
+			_, err := newComponent(gitURL, nil, true).GetBranches()
+			Expect(err).To(MatchError(ContainSubstring("401")))
+
+			// The error isn't taken for a missing branch when looking up a single branch
+			_, err = newComponent(gitURL, []string{"main"}, false).GetBranches()
+			Expect(err).To(MatchError(ContainSubstring("401")))
+		})
+	})
+
+	Context("When generating the Renovate config", func() {
+		It("should use the bitbucket-server platform", func() {
+			renovateConfig, err := newComponent(gitURL, []string{"main"}, false).GetRenovateConfig(nil, "main")
+			Expect(err).NotTo(HaveOccurred())
+
+			var config map[string]interface{}
+			Expect(json.Unmarshal([]byte(renovateConfig), &config)).To(Succeed())
+			Expect(config["platform"]).To(Equal("bitbucket-server"))
+			Expect(config["endpoint"]).To(Equal(server.URL + "/"))
+			Expect(config["onboarding"]).To(BeFalse())
+			Expect(config["repositories"]).To(Equal([]interface{}{
+				map[string]interface{}{
+					"baseBranchPatterns": []interface{}{"main"},
+					"repository":         "PROJ/repo",
+				},
+			}))
+		})
+	})
+})
@@ -19,10 +19,13 @@ import (
 	"fmt"
 
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	appstudiov1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
 
+	bitbucket "github.com/konflux-ci/mintmaker/internal/component/bitbucket"
+	gitea "github.com/konflux-ci/mintmaker/internal/component/gitea"
 	github "github.com/konflux-ci/mintmaker/internal/component/github"
 	gitlab "github.com/konflux-ci/mintmaker/internal/component/gitlab"
 	utils "github.com/konflux-ci/mintmaker/internal/utils"
@@ -37,9 +40,11 @@ type GitComponent interface {
 	GetGitURL() string
 	GetRepository() string
 	GetToken() (string, error)
+	GetCredentialSource() string
 	GetBranches() ([]string, error)
 	GetAPIEndpoint() string
 	GetRenovateConfig(*corev1.Secret, string) (string, error)
+	SetRenovateOverrides([]byte)
 	GetRPMActivationKey(context.Context, client.Client) (string, string, error)
 }
 
@@ -50,6 +55,8 @@ func NewGitComponent(ctx context.Context, comp *appstudiov1alpha1.Component, cli
 		return nil, err
 	}
 
+	// Gitea and Forgejo hosts can't be told by their name, they are only
+	// known from the platforms of the controller config
 	platform, err := utils.GetGitPlatform(gitUrl)
 	if err != nil {
 		return nil, err
@@ -68,11 +75,58 @@ func NewGitComponent(ctx context.Context, comp *appstudiov1alpha1.Component, cli
 			return nil, fmt.Errorf("error creating git component: %w", err)
 		}
 		return c, nil
+	case "bitbucket":
+		c, err := bitbucket.NewComponent(ctx, comp, client, gitUrl, GetVersions(comp), oldCRDVersion)
+		if err != nil {
+			return nil, fmt.Errorf("error creating git component: %w", err)
+		}
+		return c, nil
+	case "gitea":
+		c, err := gitea.NewComponent(ctx, comp, client, gitUrl, GetVersions(comp), oldCRDVersion)
+		if err != nil {
+			return nil, fmt.Errorf("error creating git component: %w", err)
+		}
+		return c, nil
 	default:
 		return nil, fmt.Errorf("unsupported platform: %s", platform)
 	}
 }
 
+// NewGitComponentFromURL returns a GitComponent for a git repository which is not
+// modeled as a Konflux Component. Credentials which are stored in secrets, e.g. the
+// GitLab scm secrets, are looked up in the given namespace. If no branches are
+// given, the default branch of the repository is used.
+func NewGitComponentFromURL(ctx context.Context, gitURL string, branches []string, namespace string, client client.Client) (GitComponent, error) {
+	comp := &appstudiov1alpha1.Component{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+		},
+	}
+	if len(branches) == 0 {
+		// The old component model falls back to the default branch
+		comp.Spec.Source.GitSource = &appstudiov1alpha1.GitSource{URL: gitURL}
+	} else {
+		comp.Spec.Source.GitURL = gitURL
+		for _, branch := range branches {
+			comp.Spec.Source.Versions = append(comp.Spec.Source.Versions, appstudiov1alpha1.ComponentVersion{Revision: branch})
+		}
+	}
+	return NewGitComponent(ctx, comp, client)
+}
+
+// GetRepository returns the repository of the git URL the way its GitComponent
+// names it, e.g. PROJECT/repo for the scm/PROJECT/repo path of a Bitbucket URL
+func GetRepository(gitURL string) (string, error) {
+	platform, err := utils.GetGitPlatform(gitURL)
+	if err != nil {
+		return "", err
+	}
+	if platform == "bitbucket" {
+		return bitbucket.GetRepository(gitURL)
+	}
+	return utils.GetGitPath(gitURL)
+}
+
 // GetGitURL returns the git URL for the component
 // It supports both the old and new component models
 // It returns a boolean indicating if the component is using the old model
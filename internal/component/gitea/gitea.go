@@ -0,0 +1,267 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package gitea implements the git component of repositories hosted on Gitea
+// and on its fork Forgejo, which share the same REST API.
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appstudiov1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
+
+	"github.com/konflux-ci/mintmaker/internal/component/base"
+	"github.com/konflux-ci/mintmaker/internal/config"
+	"github.com/konflux-ci/mintmaker/internal/utils"
+)
+
+const (
+	// branchesPerPage is the default maximum page size of the Gitea API
+	branchesPerPage = 50
+	// apiTimeout bounds every request to the Gitea API
+	apiTimeout = 30 * time.Second
+)
+
+// errNotFound is wrapped by the errors of API requests for missing resources
+var errNotFound = errors.New("404 Not Found")
+
+type Component struct {
+	base.BaseComponent
+	client client.Client
+	ctx    context.Context
+	// baseURL is the scheme and host (with port) of the Gitea instance
+	baseURL string
+}
+
+// repository is the subset of a Gitea repository used by the component
+type repository struct {
+	DefaultBranch string `json:"default_branch"`
+}
+
+// branch is the subset of a Gitea branch used by the component
+type branch struct {
+	Name string `json:"name"`
+}
+
+func NewComponent(ctx context.Context, comp *appstudiov1alpha1.Component, client client.Client, giturl string, versions []string, oldCRDVersion bool) (*Component, error) {
+	host, err := utils.GetGitHost(giturl)
+	if err != nil {
+		return nil, err
+	}
+	repository, err := utils.GetGitPath(giturl)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Component{
+		BaseComponent: base.BaseComponent{
+			Name:          comp.Name,
+			Namespace:     comp.Namespace,
+			Application:   comp.Spec.Application,
+			Platform:      "gitea",
+			Host:          host,
+			GitURL:        giturl,
+			Repository:    repository,
+			Versions:      versions,
+			OldCRDVersion: oldCRDVersion,
+		},
+		client:  client,
+		ctx:     ctx,
+		baseURL: baseURL(giturl, host),
+	}, nil
+}
+
+// baseURL returns the URL of the web server of the git URL. SSH URLs don't
+// carry its scheme and port, HTTPS on the default port is assumed.
+func baseURL(giturl, host string) string {
+	if u, err := url.Parse(giturl); err == nil && (u.Scheme == "http" || u.Scheme == "https") {
+		return u.Scheme + "://" + u.Host
+	}
+	return "https://" + host
+}
+
+func (c *Component) GetBranches() ([]string, error) {
+	if len(c.Versions) == 0 && c.OldCRDVersion {
+		defaultBranch, err := c.getDefaultBranch()
+		if err != nil {
+			return []string{}, fmt.Errorf("component does not have a branch specified and failed to get default branch: %w", err)
+		}
+		return []string{defaultBranch}, nil
+	}
+
+	token, err := c.GetToken()
+	if err != nil {
+		return []string{}, fmt.Errorf("failed to get Gitea token: %w", err)
+	}
+
+	branchExists := func(name string) (bool, error) {
+		// Branch names may contain slashes, which are part of the API path
+		segments := strings.Split(name, "/")
+		for i, segment := range segments {
+			segments[i] = url.PathEscape(segment)
+		}
+		err := getJSON(c.ctx, c.repositoryURL("branches/"+strings.Join(segments, "/")), token, &branch{})
+		if errors.Is(err, errNotFound) {
+			return false, nil
+		}
+		return err == nil, err
+	}
+	listBranches := func() ([]string, error) {
+		var names []string
+		for page := 1; ; page++ {
+			var branches []branch
+			query := url.Values{"page": {strconv.Itoa(page)}, "limit": {strconv.Itoa(branchesPerPage)}}
+			if err := getJSON(c.ctx, c.repositoryURL("branches?"+query.Encode()), token, &branches); err != nil {
+				return nil, err
+			}
+			for _, b := range branches {
+				names = append(names, b.Name)
+			}
+			// The instance may cap the page size below the requested limit
+			if len(branches) == 0 {
+				return names, nil
+			}
+		}
+	}
+	branches, err := base.ResolveBranches(c.Versions, branchExists, listBranches, config.Get().Branches.MaxPatternMatches)
+	if err != nil {
+		return []string{}, err
+	}
+
+	if len(branches) == 0 {
+		return []string{}, fmt.Errorf("no versions found or all versions are tags (not branches)")
+	}
+
+	return branches, nil
+}
+
+func (c *Component) GetToken() (string, error) {
+
+	secret, err := c.LookupSecret(c.ctx, c.client)
+	if err != nil {
+		return "", err
+	}
+	return string(secret.Data[corev1.BasicAuthPasswordKey]), nil
+}
+
+func (c *Component) GetAPIEndpoint() string {
+	if endpoint := config.Get().APIEndpoint(c.Host); endpoint != "" {
+		return endpoint
+	}
+	return c.baseURL + "/api/v1/"
+}
+
+func (c *Component) getDefaultBranch() (string, error) {
+	token, err := c.GetToken()
+	if err != nil {
+		return "", fmt.Errorf("failed to get Gitea token: %w", err)
+	}
+
+	repo := &repository{}
+	if err := getJSON(c.ctx, c.repositoryURL(""), token, repo); err != nil {
+		return "", err
+	}
+	if repo.DefaultBranch == "" {
+		return "", fmt.Errorf("default branch is empty in Gitea API response")
+	}
+
+	return repo.DefaultBranch, nil
+}
+
+// repositoryURL returns the API URL of the resource of the repository
+func (c *Component) repositoryURL(resource string) string {
+	repoURL := c.GetAPIEndpoint() + "repos/" + c.Repository
+	if resource != "" {
+		repoURL += "/" + resource
+	}
+	return repoURL
+}
+
+// getJSON sends a GET request to the Gitea API, authenticated by the token if
+// it isn't empty, and decodes the JSON response into out
+func getJSON(ctx context.Context, apiURL, token string, out interface{}) error {
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "token "+token)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to request Gitea API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("gitea API request %s failed: %w", req.URL.Path, errNotFound)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("gitea API request %s failed: %s", req.URL.Path, resp.Status)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode Gitea API response: %w", err)
+	}
+	return nil
+}
+
+func (c *Component) GetRenovateConfig(registrySecret *corev1.Secret, currentBranch string) (string, error) {
+	baseConfig, err := c.GetRenovateBaseConfig(c.ctx, c.client)
+	if err != nil {
+		return "", err
+	}
+
+	// Add component-specific hostRules if registrySecret is provided
+	if registrySecret != nil {
+		hostRules, err := c.GetHostRules(c.ctx, registrySecret)
+		if err == nil && len(hostRules) > 0 {
+			baseConfig["hostRules"] = hostRules
+		}
+	}
+
+	// Renovate's gitea platform covers Forgejo as well
+	baseConfig["platform"] = c.Platform
+	baseConfig["endpoint"] = c.GetAPIEndpoint()
+	// Renovate looks up the user of the token for the commit author
+	baseConfig["username"] = ""
+	baseConfig["gitAuthor"] = ""
+
+	repo := map[string]interface{}{
+		"baseBranchPatterns": []string{currentBranch},
+		"repository":         c.Repository,
+	}
+	baseConfig["repositories"] = []interface{}{repo}
+
+	updatedConfig, err := json.MarshalIndent(baseConfig, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("error marshaling updated Renovate config: %v", err)
+	}
+
+	return string(updatedConfig), nil
+}
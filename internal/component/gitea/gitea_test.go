@@ -0,0 +1,186 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	appstudiov1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
+)
+
+const testToken = "gitea-token"
+
+// newFakeGitea returns a fake of the Gitea API serving the repository
+// org/repo, which returns at most two branches per page
+func newFakeGitea(branches []string, defaultBranch string) *httptest.Server {
+	const repoPath = "/api/v1/repos/org/repo"
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/version" {
+			_ = json.NewEncoder(w).Encode(map[string]string{"version": "9.0.0+gitea-1.22.0"})
+			return
+		}
+		if r.Header.Get("Authorization") != "token "+testToken {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch {
+		case r.URL.Path == repoPath:
+			_ = json.NewEncoder(w).Encode(map[string]string{"default_branch": defaultBranch})
+		case r.URL.Path == repoPath+"/branches":
+			page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+			start := min((page-1)*2, len(branches))
+			end := min(start+2, len(branches))
+			values := []map[string]string{}
+			for _, branch := range branches[start:end] {
+				values = append(values, map[string]string{"name": branch})
+			}
+			_ = json.NewEncoder(w).Encode(values)
+		case strings.HasPrefix(r.URL.Path, repoPath+"/branches/"):
+			name := strings.TrimPrefix(r.URL.Path, repoPath+"/branches/")
+			if !slices.Contains(branches, name) {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]string{"name": name})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+var _ = Describe("Gitea component", func() {
+	var (
+		server    *httptest.Server
+		k8sClient client.Client
+		gitURL    string
+	)
+
+	newComponent := func(versions []string, oldCRDVersion bool) *Component {
+		comp := &appstudiov1alpha1.Component{
+			ObjectMeta: metav1.ObjectMeta{Name: "testcomp", Namespace: "testnamespace"},
+			Spec:       appstudiov1alpha1.ComponentSpec{Application: "testapp"},
+		}
+		c, err := NewComponent(context.Background(), comp, k8sClient, gitURL, versions, oldCRDVersion)
+		Expect(err).NotTo(HaveOccurred())
+		return c
+	}
+
+	BeforeEach(func() {
+		server = newFakeGitea([]string{"main", "release-1", "release-2", "release/3", "feature"}, "main")
+		gitURL = server.URL + "/org/repo.git"
+
+		scheme := runtime.NewScheme()
+		Expect(corev1.AddToScheme(scheme)).To(Succeed())
+		k8sClient = fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "gitea",
+					Namespace: "testnamespace",
+					Labels: map[string]string{
+						"appstudio.redhat.com/credentials": "scm",
+						"appstudio.redhat.com/scm.host":    "127.0.0.1",
+					},
+				},
+				Type: corev1.SecretTypeBasicAuth,
+				Data: map[string][]byte{
+					corev1.BasicAuthUsernameKey: []byte("user"),
+					corev1.BasicAuthPasswordKey: []byte(testToken),
+				},
+			},
+			&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "mintmaker", Name: "renovate-config"},
+				Data: map[string]string{
+					"renovate.json":    `{"extends": ["config:recommended"]}`,
+					"self_hosted.json": `{"onboarding": false}`,
+				},
+			},
+		).Build()
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Context("When getting the branches", func() {
+		It("should return the versions which are branches", func() {
+			branches, err := newComponent([]string{"main", "v1.0", "release/3"}, false).GetBranches()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(branches).To(Equal([]string{"main", "release/3"}))
+		})
+
+		It("should resolve patterns over all pages of branches", func() {
+			branches, err := newComponent([]string{"release-*"}, false).GetBranches()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(branches).To(Equal([]string{"release-2", "release-1"}))
+		})
+
+		It("should return the default branch for the old component model", func() {
+			branches, err := newComponent(nil, true).GetBranches()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(branches).To(Equal([]string{"main"}))
+		})
+
+		It("should fail if no version is a branch", func() {
+			_, err := newComponent([]string{"v1.0"}, false).GetBranches()
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should fail if the token is rejected", func() {
+			secret := &corev1.Secret{}
+			Expect(k8sClient.Get(context.Background(), client.ObjectKey{Namespace: "testnamespace", Name: "gitea"}, secret)).To(Succeed())
+			secret.Data[corev1.BasicAuthPasswordKey] = []byte("revoked")
+			Expect(k8sClient.Update(context.Background(), secret)).To(Succeed())
+
+			_, err := newComponent(nil, true).GetBranches()
+			Expect(err).To(MatchError(ContainSubstring("401")))
+
+			// The error isn't taken for a missing branch when looking up a single branch
+			_, err = newComponent([]string{"main"}, false).GetBranches()
+			Expect(err).To(MatchError(ContainSubstring("401")))
+		})
+	})
+
+	Context("When generating the Renovate config", func() {
+		It("should use the gitea platform and API endpoint", func() {
+			renovateConfig, err := newComponent([]string{"main"}, false).GetRenovateConfig(nil, "main")
+			Expect(err).NotTo(HaveOccurred())
+
+			var config map[string]interface{}
+			Expect(json.Unmarshal([]byte(renovateConfig), &config)).To(Succeed())
+			Expect(config["platform"]).To(Equal("gitea"))
+			Expect(config["endpoint"]).To(Equal(server.URL + "/api/v1/"))
+			Expect(config["repositories"]).To(Equal([]interface{}{
+				map[string]interface{}{
+					"baseBranchPatterns": []interface{}{"main"},
+					"repository":         "org/repo",
+				},
+			}))
+		})
+	})
+})
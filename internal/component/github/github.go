@@ -17,6 +17,7 @@ package github
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -40,20 +41,39 @@ import (
 
 //TODO: doc about only supporting GitHub with the installed GitHub App
 
+const (
+	// defaultAPIEndpoint is the API endpoint of github.com
+	defaultAPIEndpoint = "https://api.github.com/"
+	// defaultAppSecretName is the secret in the MintMaker namespace holding
+	// the GitHub App of hosts which don't have their own
+	defaultAppSecretName = "pipelines-as-code-secret"
+)
+
 var (
-	ghAppInstallationsCache     *StaleAllowedCache
-	ghAppInstallationInitOnce   sync.Once
-	ghAppInstallationTokenCache TokenCache
-	ghAppID                     int64
-	ghAppPrivateKey             []byte
-	ghUserID                    int64
-	ghAppSlug                   string
+	// ghApps holds the GitHub App of every host, GitHub Enterprise Server
+	// instances have their own Apps
+	ghApps                      = map[string]*ghApp{}
+	ghAppsMutex                 sync.Mutex
+	ghAppInstallationTokenCache = TokenCache{entries: map[string]TokenInfo{}}
 	// vars for mocking purposes, during testing
 	GetRenovateConfigFn func(registrySecret *corev1.Secret, currentBranch string) (string, error)
 	GetTokenFn          func() (string, error)
 	GetBranchesFn       func() ([]string, error)
 )
 
+// ErrAppNotInstalled is returned when the repository isn't accessible by any
+// installation of the GitHub App.
+var ErrAppNotInstalled = errors.New("GitHub App is not installed")
+
+// ghApp is the GitHub App of a host and what has been looked up about it
+type ghApp struct {
+	id            int64
+	privateKey    []byte
+	slug          string
+	botUserID     int64
+	installations *StaleAllowedCache
+}
+
 type AppInstallation struct {
 	InstallationID int64
 	Repositories   []string
@@ -63,36 +83,50 @@ type Component struct {
 	base.BaseComponent
 	AppID         int64
 	AppPrivateKey []byte
+	app           *ghApp
 	client        client.Client
 	ctx           context.Context
+	// credentialSource is set by GetToken, the GitHub App or an scm secret
+	// holding an access token if the App isn't installed for the repository
+	credentialSource string
 }
 
-func getAppIDAndKey(ctx context.Context, client client.Client) (int64, []byte, error) {
-	if ghAppID != 0 && ghAppPrivateKey != nil {
-		return ghAppID, ghAppPrivateKey, nil
+// appSecretName returns the name of the secret holding the GitHub App of the
+// host, which can be configured per host in the platforms config
+func appSecretName(host string) string {
+	if platform, ok := config.Get().PlatformForHost(host); ok && platform.GitHubAppSecret != "" {
+		return platform.GitHubAppSecret
+	}
+	return defaultAppSecretName
+}
+
+func getApp(ctx context.Context, client client.Client, host string) (*ghApp, error) {
+	ghAppsMutex.Lock()
+	defer ghAppsMutex.Unlock()
+	if app, ok := ghApps[host]; ok {
+		return app, nil
 	}
 	//Check if GitHub Application is used, if not then skip
 	appSecret := corev1.Secret{}
-	appSecretKey := types.NamespacedName{Namespace: "mintmaker", Name: "pipelines-as-code-secret"}
+	appSecretKey := types.NamespacedName{Namespace: "mintmaker", Name: appSecretName(host)}
 	if err := client.Get(ctx, appSecretKey, &appSecret); err != nil {
-		return 0, nil, err
+		return nil, err
 	}
 
 	// validate content of the fields
 	num, err := strconv.ParseInt(string(appSecret.Data["github-application-id"]), 10, 64)
 	if err != nil {
-		return 0, nil, fmt.Errorf("failed to parse GitHub APP ID: %w", err)
+		return nil, fmt.Errorf("failed to parse GitHub APP ID: %w", err)
+	}
+	app := &ghApp{
+		id:         num,
+		privateKey: appSecret.Data["github-private-key"],
 	}
-	ghAppID = num
-	ghAppPrivateKey = appSecret.Data["github-private-key"]
-	return ghAppID, ghAppPrivateKey, nil
+	ghApps[host] = app
+	return app, nil
 }
 
 func NewComponent(ctx context.Context, comp *appstudiov1alpha1.Component, client client.Client, giturl string, versions []string, oldCRDVersion bool) (*Component, error) {
-	appID, appPrivateKey, err := getAppIDAndKey(ctx, client)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get GitHub APP ID and private key: %w", err)
-	}
 	// TODO: a helper to validate and parse the git url
 	platform, err := utils.GetGitPlatform(giturl)
 	if err != nil {
@@ -102,6 +136,10 @@ func NewComponent(ctx context.Context, comp *appstudiov1alpha1.Component, client
 	if err != nil {
 		return nil, err
 	}
+	app, err := getApp(ctx, client, host)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get GitHub APP ID and private key: %w", err)
+	}
 	repository, err := utils.GetGitPath(giturl)
 	if err != nil {
 		return nil, err
@@ -119,8 +157,9 @@ func NewComponent(ctx context.Context, comp *appstudiov1alpha1.Component, client
 			Versions:      versions,
 			OldCRDVersion: oldCRDVersion,
 		},
-		AppID:         appID,
-		AppPrivateKey: appPrivateKey,
+		AppID:         app.id,
+		AppPrivateKey: app.privateKey,
+		app:           app,
 		client:        client,
 		ctx:           ctx,
 	}, nil
@@ -139,7 +178,6 @@ func (c *Component) GetBranches() ([]string, error) {
 		return []string{defaultBranch}, nil
 	}
 
-	var branches []string
 	client, err := c.getClient()
 	if err != nil {
 		return []string{}, fmt.Errorf("GetBranches: failed to get GitHub client: %w", err)
@@ -149,12 +187,31 @@ func (c *Component) GetBranches() ([]string, error) {
 		return []string{}, fmt.Errorf("GetBranches: failed to get owner and repository: %w", err)
 	}
 
-	for _, version := range c.Versions {
-		_, _, err := client.Repositories.GetBranch(context.Background(), owner, repo, version, 5)
-		if err == nil {
-			branches = append(branches, version)
+	branchExists := func(branch string) (bool, error) {
+		_, _, err := client.Repositories.GetBranch(context.Background(), owner, repo, branch, 5)
+		return err == nil, nil
+	}
+	listBranches := func() ([]string, error) {
+		var names []string
+		opts := &github.BranchListOptions{ListOptions: github.ListOptions{PerPage: 100}}
+		for {
+			page, resp, err := client.Repositories.ListBranches(context.Background(), owner, repo, opts)
+			if err != nil {
+				return nil, err
+			}
+			for _, branch := range page {
+				names = append(names, branch.GetName())
+			}
+			if resp.NextPage == 0 {
+				return names, nil
+			}
+			opts.Page = resp.NextPage
 		}
 	}
+	branches, err := base.ResolveBranches(c.Versions, branchExists, listBranches, config.Get().Branches.MaxPatternMatches)
+	if err != nil {
+		return []string{}, fmt.Errorf("GetBranches: %w", err)
+	}
 
 	if len(branches) == 0 {
 		return []string{}, fmt.Errorf("no versions found or all versions are tags (not branches)")
@@ -186,7 +243,7 @@ func (c *Component) getInstallationID() (int64, error) {
 		}
 	}
 	if !found {
-		return 0, fmt.Errorf("repository %s not found in any GitHub App installation", c.Repository)
+		return 0, fmt.Errorf("%w: repository %s not found in any GitHub App installation", ErrAppNotInstalled, c.Repository)
 	}
 	return installationID, nil
 }
@@ -198,29 +255,33 @@ func (c *Component) GetToken() (string, error) {
 	}
 
 	installationID, err := c.getInstallationID()
+	if errors.Is(err, ErrAppNotInstalled) {
+		// Fall back to a personal or fine-grained access token, if the
+		// namespace of the component has one for the repository
+		secret, secretErr := c.LookupSecret(c.ctx, c.client)
+		if secretErr == nil {
+			c.credentialSource = base.CredentialSourceScmSecret
+			return string(secret.Data[corev1.BasicAuthPasswordKey]), nil
+		}
+		err = fmt.Errorf("%w, and no access token found: %v", err, secretErr)
+	}
 	if err != nil {
 		return "", fmt.Errorf("failed to get installation ID: %w", err)
 	}
+	c.credentialSource = base.CredentialSourceGitHubApp
 
-	tokenKey := fmt.Sprintf("installation_%d", installationID)
+	// Installation IDs are only unique per host
+	tokenKey := fmt.Sprintf("%s/installation_%d", c.Host, installationID)
 	cfg := config.Get().GitHub
-	if ghAppInstallationTokenCache.entries == nil {
-		ghAppInstallationTokenCache.entries = make(map[string]TokenInfo)
-	}
 
 	// when token exists and within the threshold, a valid token is returned
 	if tokenInfo, ok := ghAppInstallationTokenCache.Get(tokenKey); ok {
 		return tokenInfo.Token, nil
 	}
 	// when token doesn't exist or not within the threshold, we generate a new token and update the cache
-	itr, err := ghinstallation.New(
-		http.DefaultTransport,
-		c.AppID,
-		installationID,
-		c.AppPrivateKey,
-	)
+	itr, err := c.installationTransport(installationID)
 	if err != nil {
-		return "", fmt.Errorf("error creating installation transport: %w", err)
+		return "", err
 	}
 	token, err := itr.Token(context.Background())
 	if err != nil {
@@ -235,17 +296,20 @@ func (c *Component) GetToken() (string, error) {
 }
 
 func (c *Component) getAppInstallations() ([]AppInstallation, error) {
-	// Initialize the cache if it hasn't been initialized yet
-	ghAppInstallationInitOnce.Do(func() {
-		ghAppInstallationsCache = NewStaleAllowedCache(2*time.Hour, func() (interface{}, error) {
+	// Initialize the cache of the host if it hasn't been initialized yet
+	ghAppsMutex.Lock()
+	if c.app.installations == nil {
+		c.app.installations = NewStaleAllowedCache(2*time.Hour, func() (interface{}, error) {
 			return c.fetchAppInstallations()
 		})
-	})
+	}
+	installationsCache := c.app.installations
+	ghAppsMutex.Unlock()
 
 	// Get from cache - this will block until initial data is loaded if this is the first access.
 	// May return stale data if a background refresh is in progress, which is acceptable for
 	// app installation data since it's not a hard requirement to process with real-time data.
-	data, ok := ghAppInstallationsCache.Get("installations")
+	data, ok := installationsCache.Get("installations")
 	if !ok {
 		return nil, fmt.Errorf("failed to get GitHub app installations")
 	}
@@ -258,12 +322,10 @@ func (c *Component) getAppInstallations() ([]AppInstallation, error) {
 func (c *Component) fetchAppInstallations() ([]AppInstallation, error) {
 	var appInstallations []AppInstallation
 
-	itr, err := ghinstallation.NewAppsTransport(http.DefaultTransport, c.AppID, c.AppPrivateKey)
+	client, err := c.newAppClient()
 	if err != nil {
 		return nil, err
 	}
-
-	client := github.NewClient(&http.Client{Transport: itr})
 	_, _, err = client.Apps.Get(context.Background(), "")
 	if err != nil {
 		return nil, fmt.Errorf("failed to load GitHub app metadata, %w", err)
@@ -290,12 +352,15 @@ func (c *Component) fetchAppInstallations() ([]AppInstallation, error) {
 				InstallationID: installation.GetID(),
 			}
 
-			itr, err := ghinstallation.New(http.DefaultTransport, c.AppID, installation.GetID(), c.AppPrivateKey)
+			itr, err := c.installationTransport(installation.GetID())
 			if err != nil {
-				return nil, fmt.Errorf("error creating installation transport: %w", err)
+				return nil, err
 			}
 
-			installationClient := github.NewClient(&http.Client{Transport: itr})
+			installationClient, err := c.newClient(&http.Client{Transport: itr})
+			if err != nil {
+				return nil, err
+			}
 			repoOpt := &github.ListOptions{PerPage: 100}
 			for {
 				repos, repoResp, err := installationClient.Apps.ListRepos(context.Background(), repoOpt)
@@ -346,48 +411,110 @@ func (c *Component) getDefaultBranch() (string, error) {
 	return *repositoryInfo.DefaultBranch, nil
 }
 
+// GetAPIEndpoint returns the API endpoint of the host: api.github.com for
+// github.com and /api/v3/ on GitHub Enterprise Server, unless configured
 func (c *Component) GetAPIEndpoint() string {
-	return fmt.Sprintf("https://api.%s/", c.Host)
+	if endpoint := config.Get().APIEndpoint(c.Host); endpoint != "" {
+		return endpoint
+	}
+	if c.Host == "github.com" {
+		return defaultAPIEndpoint
+	}
+	return fmt.Sprintf("https://%s/api/v3/", c.Host)
 }
 
-func (c *Component) getAppSlug() (string, error) {
-	if ghAppSlug != "" {
-		return ghAppSlug, nil
+// newClient returns a GitHub client for the API of the host
+func (c *Component) newClient(httpClient *http.Client) (*github.Client, error) {
+	client := github.NewClient(httpClient)
+	endpoint := c.GetAPIEndpoint()
+	if endpoint == defaultAPIEndpoint {
+		return client, nil
 	}
+	client, err := client.WithEnterpriseURLs(endpoint, endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GitHub Enterprise client: %w", err)
+	}
+	return client, nil
+}
 
-	appID, appPrivateKey, err := getAppIDAndKey(c.ctx, c.client)
+// newAppClient returns a GitHub client authenticated as the GitHub App
+func (c *Component) newAppClient() (*github.Client, error) {
+	itr, err := ghinstallation.NewAppsTransport(http.DefaultTransport, c.AppID, c.AppPrivateKey)
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+	itr.BaseURL = strings.TrimSuffix(c.GetAPIEndpoint(), "/")
+	return c.newClient(&http.Client{Transport: itr})
+}
+
+// installationTransport returns a transport authenticated as the installation
+// of the GitHub App
+func (c *Component) installationTransport(installationID int64) (*ghinstallation.Transport, error) {
+	itr, err := ghinstallation.New(http.DefaultTransport, c.AppID, installationID, c.AppPrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("error creating installation transport: %w", err)
 	}
-	itr, err := ghinstallation.NewAppsTransport(http.DefaultTransport, appID, appPrivateKey)
+	itr.BaseURL = strings.TrimSuffix(c.GetAPIEndpoint(), "/")
+	return itr, nil
+}
+
+func (c *Component) getAppSlug() (string, error) {
+	ghAppsMutex.Lock()
+	slug := c.app.slug
+	ghAppsMutex.Unlock()
+	if slug != "" {
+		return slug, nil
+	}
+
+	client, err := c.newAppClient()
 	if err != nil {
 		return "", err
 	}
-
-	client := github.NewClient(&http.Client{Transport: itr})
 	app, _, err := client.Apps.Get(context.Background(), "")
 	if err != nil {
 		return "", fmt.Errorf("failed to load GitHub app metadata, %w", err)
 	}
 
-	ghAppSlug = app.GetSlug()
-	return ghAppSlug, nil
+	ghAppsMutex.Lock()
+	c.app.slug = app.GetSlug()
+	ghAppsMutex.Unlock()
+	return app.GetSlug(), nil
 }
 
 func (c *Component) getUserId(username string) (int64, error) {
-	if ghUserID != 0 {
-		return ghUserID, nil
+	ghAppsMutex.Lock()
+	userID := c.app.botUserID
+	ghAppsMutex.Unlock()
+	if userID != 0 {
+		return userID, nil
+	}
+	// The User API is public on github.com, but GitHub Enterprise Server
+	// instances may require authentication
+	client, err := c.getClient()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get GitHub client: %w", err)
 	}
-	// No need to add auth here as User API is public
-	client := github.NewClient(&http.Client{})
 
 	user, _, err := client.Users.Get(context.Background(), username)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get user information: %w", err)
 	}
 
-	ghUserID = user.GetID()
-	return ghUserID, nil
+	ghAppsMutex.Lock()
+	c.app.botUserID = user.GetID()
+	ghAppsMutex.Unlock()
+	return user.GetID(), nil
+}
+
+// GetCredentialSource returns the source of the token returned by GetToken,
+// empty if no token has been returned yet
+func (c *Component) GetCredentialSource() string {
+	return c.credentialSource
+}
+
+// noreplyDomain returns the domain of the noreply email addresses of the host
+func (c *Component) noreplyDomain() string {
+	return "users.noreply." + c.Host
 }
 
 func (c *Component) GetRenovateConfig(registrySecret *corev1.Secret, currentBranch string) (string, error) {
@@ -407,17 +534,28 @@ func (c *Component) GetRenovateConfig(registrySecret *corev1.Secret, currentBran
 			baseConfig["hostRules"] = hostRules
 		}
 	}
-	appSlug, err := c.getAppSlug()
-	if err != nil {
-		return "", err
-	}
-	botId, err := c.getUserId(appSlug + "[bot]")
-	if err != nil {
-		return "", err
+	if c.credentialSource == "" {
+		if _, err := c.GetToken(); err != nil {
+			return "", err
+		}
 	}
+	if c.credentialSource == base.CredentialSourceScmSecret {
+		// Renovate looks up the user of an access token itself
+		baseConfig["gitAuthor"] = ""
+		baseConfig["username"] = ""
+	} else {
+		appSlug, err := c.getAppSlug()
+		if err != nil {
+			return "", err
+		}
+		botId, err := c.getUserId(appSlug + "[bot]")
+		if err != nil {
+			return "", err
+		}
 
-	baseConfig["gitAuthor"] = fmt.Sprintf("%s <%d+%s[bot]@users.noreply.github.com>", appSlug, botId, appSlug)
-	baseConfig["username"] = fmt.Sprintf("%s[bot]", appSlug)
+		baseConfig["gitAuthor"] = fmt.Sprintf("%s <%d+%s[bot]@%s>", appSlug, botId, appSlug, c.noreplyDomain())
+		baseConfig["username"] = fmt.Sprintf("%s[bot]", appSlug)
+	}
 	baseConfig["platform"] = c.Platform
 	baseConfig["endpoint"] = c.GetAPIEndpoint()
 
@@ -444,9 +582,8 @@ func (c *Component) getClient() (*github.Client, error) {
 		&oauth2.Token{AccessToken: token},
 	)
 	tc := oauth2.NewClient(context.Background(), ts)
-	client := github.NewClient(tc)
 
-	return client, nil
+	return c.newClient(tc)
 }
 
 func (c *Component) getOwnerAndRepo() (string, string, error) {
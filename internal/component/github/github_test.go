@@ -0,0 +1,250 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	appstudiov1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
+
+	"github.com/konflux-ci/mintmaker/internal/component/base"
+	"github.com/konflux-ci/mintmaker/internal/config"
+)
+
+const (
+	ghesInstallationToken = "ghs_installation_token"
+	ghesAppSlug           = "mintmaker-ghes"
+)
+
+// ghesServer is a fake of the API of a GitHub Enterprise Server instance,
+// configured for the host 127.0.0.1 in the controller config
+var ghesServer *httptest.Server
+
+// newFakeGHES returns a fake of the GitHub Enterprise Server API, where the
+// GitHub App has one installation with access to org/repo
+func newFakeGHES() *httptest.Server {
+	writeJSON := func(w http.ResponseWriter, v interface{}) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(v)
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v3/app", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]interface{}{"id": 1, "slug": ghesAppSlug})
+	})
+	mux.HandleFunc("GET /api/v3/app/installations", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, []map[string]interface{}{{"id": 7}})
+	})
+	mux.HandleFunc("POST /api/v3/app/installations/7/access_tokens", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		writeJSON(w, map[string]interface{}{
+			"token":      ghesInstallationToken,
+			"expires_at": time.Now().Add(time.Hour).Format(time.RFC3339),
+		})
+	})
+	mux.HandleFunc("GET /api/v3/installation/repositories", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]interface{}{
+			"total_count":  1,
+			"repositories": []map[string]interface{}{{"full_name": "org/repo"}},
+		})
+	})
+	// The user API of the instance requires authentication
+	mux.HandleFunc("GET /api/v3/users/{user}", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+ghesInstallationToken {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		writeJSON(w, map[string]interface{}{"id": 42, "login": r.PathValue("user")})
+	})
+	mux.HandleFunc("GET /api/v3/repos/org/repo", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]interface{}{"default_branch": "main"})
+	})
+	mux.HandleFunc("GET /api/v3/repos/org/repo/branches/{branch}", func(w http.ResponseWriter, r *http.Request) {
+		if r.PathValue("branch") != "main" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		writeJSON(w, map[string]interface{}{"name": "main"})
+	})
+	return httptest.NewServer(mux)
+}
+
+var _ = BeforeSuite(func() {
+	ghesServer = newFakeGHES()
+
+	configPath := filepath.Join(GinkgoT().TempDir(), "config.json")
+	Expect(os.WriteFile(configPath, []byte(fmt.Sprintf(`{
+		"platforms": [
+			{"host": "127.0.0.1", "type": "github", "api-endpoint": "%s/api/v3/", "github-app-secret": "ghes-app-secret"}
+		]
+	}`, ghesServer.URL)), 0o600)).To(Succeed())
+	GinkgoT().Setenv("MINTMAKER_CONFIG_PATH", configPath)
+	Expect(config.Get().Platforms).To(HaveLen(1))
+})
+
+var _ = AfterSuite(func() {
+	ghesServer.Close()
+})
+
+var _ = Describe("GitHub component", func() {
+	Context("When getting the API endpoint", func() {
+		It("should use api.github.com for github.com", func() {
+			c := &Component{BaseComponent: baseComponent("github.com")}
+			Expect(c.GetAPIEndpoint()).To(Equal("https://api.github.com/"))
+			Expect(c.noreplyDomain()).To(Equal("users.noreply.github.com"))
+		})
+
+		It("should use the enterprise API of other hosts", func() {
+			c := &Component{BaseComponent: baseComponent("github.example.com")}
+			Expect(c.GetAPIEndpoint()).To(Equal("https://github.example.com/api/v3/"))
+			Expect(c.noreplyDomain()).To(Equal("users.noreply.github.example.com"))
+		})
+	})
+
+	Context("When the repository is on GitHub Enterprise Server", func() {
+		var k8sClient client.Client
+
+		BeforeEach(func() {
+			key, err := rsa.GenerateKey(rand.Reader, 2048)
+			Expect(err).NotTo(HaveOccurred())
+			privateKey := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+
+			scheme := runtime.NewScheme()
+			Expect(corev1.AddToScheme(scheme)).To(Succeed())
+			k8sClient = fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "mintmaker", Name: "ghes-app-secret"},
+					Data: map[string][]byte{
+						"github-application-id": []byte("1"),
+						"github-private-key":    privateKey,
+					},
+				},
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "mintmaker", Name: "renovate-config"},
+					Data: map[string]string{
+						"renovate.json":    `{"extends": ["config:recommended"]}`,
+						"self_hosted.json": `{"onboarding": false}`,
+					},
+				},
+			).Build()
+		})
+
+		newComponent := func(versions []string, oldCRDVersion bool) *Component {
+			comp := &appstudiov1alpha1.Component{
+				ObjectMeta: metav1.ObjectMeta{Name: "testcomp", Namespace: "testnamespace"},
+			}
+			c, err := NewComponent(context.Background(), comp, k8sClient, ghesServer.URL+"/org/repo", versions, oldCRDVersion)
+			Expect(err).NotTo(HaveOccurred())
+			return c
+		}
+
+		It("should use the GitHub App of the host", func() {
+			c := newComponent(nil, false)
+			Expect(c.AppID).To(Equal(int64(1)))
+			Expect(c.GetAPIEndpoint()).To(Equal(ghesServer.URL + "/api/v3/"))
+
+			token, err := c.GetToken()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(token).To(Equal(ghesInstallationToken))
+			Expect(c.GetCredentialSource()).To(Equal(base.CredentialSourceGitHubApp))
+		})
+
+		It("should look up the branches on the instance", func() {
+			branches, err := newComponent([]string{"main", "v1.0"}, false).GetBranches()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(branches).To(Equal([]string{"main"}))
+
+			branches, err = newComponent(nil, true).GetBranches()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(branches).To(Equal([]string{"main"}))
+		})
+
+		It("should fall back to an access token if the App isn't installed", func() {
+			comp := &appstudiov1alpha1.Component{
+				ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "testnamespace"},
+			}
+			c, err := NewComponent(context.Background(), comp, k8sClient, ghesServer.URL+"/org/other", []string{"main"}, false)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = c.GetToken()
+			Expect(err).To(MatchError(ErrAppNotInstalled))
+			Expect(c.GetCredentialSource()).To(BeEmpty())
+
+			Expect(k8sClient.Create(context.Background(), &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "github-token",
+					Namespace: "testnamespace",
+					Labels: map[string]string{
+						"appstudio.redhat.com/credentials": "scm",
+						"appstudio.redhat.com/scm.host":    "127.0.0.1",
+					},
+					Annotations: map[string]string{"appstudio.redhat.com/scm.repository": "org/other"},
+				},
+				Type: corev1.SecretTypeBasicAuth,
+				Data: map[string][]byte{
+					corev1.BasicAuthUsernameKey: []byte("user"),
+					corev1.BasicAuthPasswordKey: []byte("github_pat_token"),
+				},
+			})).To(Succeed())
+
+			token, err := c.GetToken()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(token).To(Equal("github_pat_token"))
+			Expect(c.GetCredentialSource()).To(Equal(base.CredentialSourceScmSecret))
+
+			renovateConfig, err := c.GetRenovateConfig(nil, "main")
+			Expect(err).NotTo(HaveOccurred())
+			var config map[string]interface{}
+			Expect(json.Unmarshal([]byte(renovateConfig), &config)).To(Succeed())
+			Expect(config["username"]).To(BeEmpty())
+			Expect(config["gitAuthor"]).To(BeEmpty())
+		})
+
+		It("should generate the Renovate config for the instance", func() {
+			renovateConfig, err := newComponent([]string{"main"}, false).GetRenovateConfig(nil, "main")
+			Expect(err).NotTo(HaveOccurred())
+
+			var config map[string]interface{}
+			Expect(json.Unmarshal([]byte(renovateConfig), &config)).To(Succeed())
+			Expect(config["platform"]).To(Equal("github"))
+			Expect(config["endpoint"]).To(Equal(ghesServer.URL + "/api/v3/"))
+			Expect(config["username"]).To(Equal(ghesAppSlug + "[bot]"))
+			Expect(config["gitAuthor"]).To(Equal(
+				fmt.Sprintf("%s <42+%s[bot]@users.noreply.127.0.0.1>", ghesAppSlug, ghesAppSlug)))
+		})
+	})
+})
+
+func baseComponent(host string) base.BaseComponent {
+	return base.BaseComponent{Host: host, Platform: "github"}
+}
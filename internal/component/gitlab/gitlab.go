@@ -19,17 +19,15 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/url"
-	"strings"
 
 	gitlab "gitlab.com/gitlab-org/api/client-go"
 	corev1 "k8s.io/api/core/v1"
-	"k8s.io/utils/strings/slices"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	appstudiov1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
 
 	"github.com/konflux-ci/mintmaker/internal/component/base"
-	bslices "github.com/konflux-ci/mintmaker/internal/slices"
+	"github.com/konflux-ci/mintmaker/internal/config"
 	"github.com/konflux-ci/mintmaker/internal/utils"
 )
 
@@ -87,18 +85,36 @@ func (c *Component) GetBranches() ([]string, error) {
 		return []string{defaultBranch}, nil
 	}
 
-	var branches []string
 	client, err := c.getClient()
 	if err != nil {
 		return []string{}, fmt.Errorf("failed to get GitLab client: %w", err)
 	}
 
-	for _, version := range c.Versions {
-		_, _, err := client.Branches.GetBranch(c.Repository, version, nil)
-		if err == nil {
-			branches = append(branches, version)
+	branchExists := func(branch string) (bool, error) {
+		_, _, err := client.Branches.GetBranch(c.Repository, branch, nil)
+		return err == nil, nil
+	}
+	listBranches := func() ([]string, error) {
+		var names []string
+		opts := &gitlab.ListBranchesOptions{ListOptions: gitlab.ListOptions{PerPage: 100}}
+		for {
+			page, resp, err := client.Branches.ListBranches(c.Repository, opts)
+			if err != nil {
+				return nil, err
+			}
+			for _, branch := range page {
+				names = append(names, branch.Name)
+			}
+			if resp.NextPage == 0 {
+				return names, nil
+			}
+			opts.Page = resp.NextPage
 		}
 	}
+	branches, err := base.ResolveBranches(c.Versions, branchExists, listBranches, config.Get().Branches.MaxPatternMatches)
+	if err != nil {
+		return []string{}, err
+	}
 
 	if len(branches) == 0 {
 		return []string{}, fmt.Errorf("no versions found or all versions are tags (not branches)")
@@ -107,90 +123,9 @@ func (c *Component) GetBranches() ([]string, error) {
 	return branches, nil
 }
 
-func (c *Component) lookupSecret() (*corev1.Secret, error) {
-
-	secretList := &corev1.SecretList{}
-	opts := client.ListOption(&client.MatchingLabels{
-		"appstudio.redhat.com/credentials": "scm",
-		"appstudio.redhat.com/scm.host":    c.Host,
-	})
-
-	// find secrets that have the following labels:
-	//	- "appstudio.redhat.com/credentials": "scm"
-	//	- "appstudio.redhat.com/scm.host": <name of component host>
-	if err := c.client.List(c.ctx, secretList, client.InNamespace(c.Namespace), opts); err != nil {
-		return nil, fmt.Errorf("failed to list scm secrets in namespace %s: %w", c.Namespace, err)
-	}
-
-	// filtering to get BasicAuth secrets and data is not empty
-	secrets := bslices.Filter(secretList.Items, func(secret corev1.Secret) bool {
-		return secret.Type == corev1.SecretTypeBasicAuth && len(secret.Data) > 0
-	})
-	if len(secrets) == 0 {
-		return nil, fmt.Errorf("no secrets available for git host %s", c.Host)
-	}
-
-	// secrets only match with component's host
-	var hostOnlySecrets []corev1.Secret
-	// map of secret index and its best path intersections count, i.e. the count of path parts matched,
-	var potentialMatches = make(map[int]int, len(secrets))
-
-	for index, secret := range secrets {
-		repositoryAnnotation, exists := secret.Annotations["appstudio.redhat.com/scm.repository"]
-		if !exists || repositoryAnnotation == "" {
-			hostOnlySecrets = append(hostOnlySecrets, secret)
-			continue
-		}
-
-		secretRepositories := strings.Split(repositoryAnnotation, ",")
-		// trim possible prefix or suffix "/"
-		for i, repository := range secretRepositories {
-			secretRepositories[i] = strings.TrimPrefix(strings.TrimSuffix(repository, "/"), "/")
-		}
-
-		// this secret matches exactly the component's repository name
-		if slices.Contains(secretRepositories, c.Repository) {
-			return &secret, nil
-		}
-
-		// no direct match, check for wildcard match, i.e. org/repo/* matches org/repo/foo, org/repo/bar, etc.
-		componentRepoParts := strings.Split(c.Repository, "/")
-
-		// find wildcard repositories
-		wildcardRepos := slices.Filter(nil, secretRepositories, func(s string) bool { return strings.HasSuffix(s, "*") })
-
-		for _, repo := range wildcardRepos {
-			i := bslices.Intersection(componentRepoParts, strings.Split(strings.TrimSuffix(repo, "*"), "/"))
-			if i > 0 && potentialMatches[index] < i {
-				// add whole secret index to potential matches
-				potentialMatches[index] = i
-			}
-		}
-	}
-
-	if len(potentialMatches) == 0 {
-		if len(hostOnlySecrets) == 0 {
-			// no potential matches, no host matches, nothing to return
-			return nil, fmt.Errorf("no secrets available for component")
-		}
-		// no potential matches, but we have host match secrets, return the first one
-		return &hostOnlySecrets[0], nil
-	}
-
-	// some potential matches exist, find the best one
-	var bestIndex, bestCount int
-	for i, count := range potentialMatches {
-		if count > bestCount {
-			bestCount = count
-			bestIndex = i
-		}
-	}
-	return &secrets[bestIndex], nil
-}
-
 func (c *Component) GetToken() (string, error) {
 
-	secret, err := c.lookupSecret()
+	secret, err := c.LookupSecret(c.ctx, c.client)
 	if err != nil {
 		return "", err
 	}
@@ -198,6 +133,9 @@ func (c *Component) GetToken() (string, error) {
 }
 
 func (c *Component) GetAPIEndpoint() string {
+	if endpoint := config.Get().APIEndpoint(c.Host); endpoint != "" {
+		return endpoint
+	}
 	return fmt.Sprintf("https://%s/api/v4/", c.Host)
 }
 
@@ -264,6 +202,9 @@ func (c *Component) getClient() (*gitlab.Client, error) {
 		return nil, fmt.Errorf("failed to parse git url: %w", err)
 	}
 	baseUrl := u.Scheme + "://" + c.Host
+	if endpoint := config.Get().APIEndpoint(c.Host); endpoint != "" {
+		baseUrl = endpoint
+	}
 	client, err := gitlab.NewClient(token, gitlab.WithBaseURL(baseUrl))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create GitLab client: %w", err)
@@ -28,7 +28,35 @@
 //	  "kite": {
 //	    "enabled": true,
 //	    "api-url": "https://kite.example.com"
-//	  }
+//	  },
+//	  "scheduling": {
+//	    "max-concurrent-pipelineruns": 50,
+//	    "target-batch-size": 50,
+//	    "target-workers": 4
+//	  },
+//	  "retry": {
+//	    "max-attempts": 3,
+//	    "backoff": "5m"
+//	  },
+//	  "branches": {
+//	    "max-pattern-matches": 10
+//	  },
+//	  "platforms": [
+//	    {
+//	      "host": "git.corp.example.com",
+//	      "type": "gitlab",
+//	      "api-endpoint": "https://git.corp.example.com/api/v4/"
+//	    },
+//	    {
+//	      "host": "github.corp.example.com",
+//	      "type": "github",
+//	      "github-app-secret": "github-corp-app-secret"
+//	    },
+//	    {
+//	      "host": "*.forge.example.com",
+//	      "type": "gitea"
+//	    }
+//	  ]
 //	}
 //
 // GitHub Token Configuration:
@@ -52,11 +80,62 @@
 //     log-analyzer step is added to the pipelinerun. Defaults to false.
 //   - api-url: The URL of the Kite API endpoint. Can also be set via
 //     KITE_API_URL environment variable (config file takes precedence).
+//
+// Scheduling Configuration:
+//
+//   - max-concurrent-pipelineruns: The maximum number of unfinished
+//     PipelineRuns in the MintMaker namespace. Targets of a
+//     DependencyUpdateCheck beyond the limit are queued and scheduled as
+//     running PipelineRuns finish. Defaults to 0, which means no limit.
+//   - target-batch-size: The number of Components and repositories of a
+//     DependencyUpdateCheck processed per reconcile. The progress is stored
+//     in the status of the check after every batch. Defaults to 50.
+//   - target-workers: The number of Components and repositories of a batch
+//     whose branches are looked up in parallel. Defaults to 4.
+//
+// Retry Configuration:
+//
+// PipelineRuns which fail for transient reasons, e.g. an image pull failure,
+// a missing token or an evicted pod, are created again.
+//
+//   - max-attempts: The maximum number of PipelineRuns created for a target.
+//     Defaults to 3, 1 disables retries.
+//   - backoff: The delay before the first retry, it doubles with every
+//     further retry. Defaults to 5m.
+//
+// Branches Configuration:
+//
+// Versions of Components and branches of repositories can be patterns, e.g.
+// release-*, which are matched against the branches of the repository.
+//
+//   - max-pattern-matches: The maximum number of branches a pattern resolves
+//     to, the highest versions are taken, comparing the numbers in branch
+//     names by value. Defaults to 10, 0 means no limit.
+//
+// Platforms Configuration:
+//
+// The platform of a repository is looked up by its host in the platforms
+// list, the first matching entry wins. Hosts which aren't listed fall back to
+// their name, e.g. hosts containing "gitlab" are GitLab instances.
+//
+//   - host: The hostname of the git server, or a glob matching hostnames,
+//     e.g. *.example.com.
+//   - type: The platform of the git server, one of github, gitlab, bitbucket
+//     or gitea.
+//   - api-endpoint: The API endpoint used for requests to the git server and
+//     passed to Renovate. Defaults to the usual endpoint of the platform on
+//     the host.
+//   - github-app-secret: The secret in the MintMaker namespace holding the
+//     GitHub App of a GitHub Enterprise Server host, with the same keys as
+//     pipelines-as-code-secret. Defaults to pipelines-as-code-secret.
 package config
 
 import (
 	"encoding/json"
 	"os"
+	"path"
+	"slices"
+	"strings"
 	"sync"
 	"time"
 
@@ -65,12 +144,20 @@ import (
 )
 
 const (
-	defaultConfigPath       = "/etc/mintmaker/config.json"
-	configPathEnvVar        = "MINTMAKER_CONFIG_PATH"
-	defaultTokenTTL         = 60 * time.Minute
-	defaultTokenMinValidity = 30 * time.Minute
+	defaultConfigPath        = "/etc/mintmaker/config.json"
+	configPathEnvVar         = "MINTMAKER_CONFIG_PATH"
+	defaultTokenTTL          = 60 * time.Minute
+	defaultTokenMinValidity  = 30 * time.Minute
+	defaultTargetBatchSize   = 50
+	defaultTargetWorkers     = 4
+	defaultRetryMaxAttempts  = 3
+	defaultRetryBackoff      = 5 * time.Minute
+	defaultMaxPatternMatches = 10
 )
 
+// platformTypes are the git platforms which can be configured for hosts
+var platformTypes = []string{"github", "gitlab", "bitbucket", "gitea"}
+
 // GitHubConfig holds GitHub-related configuration.
 type GitHubConfig struct {
 	// TokenTTL is the total validity period of a GitHub installation token
@@ -99,10 +186,67 @@ type KiteConfig struct {
 	APIURL string
 }
 
+// SchedulingConfig holds configuration of PipelineRun scheduling.
+type SchedulingConfig struct {
+	// MaxConcurrentPipelineRuns is the maximum number of unfinished
+	// PipelineRuns in the MintMaker namespace, further targets are queued
+	// until running PipelineRuns finish. 0 means no limit.
+	MaxConcurrentPipelineRuns int
+
+	// TargetBatchSize is the number of Components and repositories of a
+	// DependencyUpdateCheck processed per reconcile.
+	TargetBatchSize int
+
+	// TargetWorkers is the number of Components and repositories of a batch
+	// whose branches are looked up in parallel.
+	TargetWorkers int
+}
+
+// RetryConfig holds configuration of retrying PipelineRuns which failed for
+// transient reasons.
+type RetryConfig struct {
+	// MaxAttempts is the maximum number of PipelineRuns created for a target.
+	// 1 disables retries.
+	MaxAttempts int
+
+	// Backoff is the delay before the first retry, it doubles with every
+	// further retry.
+	Backoff time.Duration
+}
+
+// BranchesConfig holds configuration of resolving branch patterns.
+type BranchesConfig struct {
+	// MaxPatternMatches is the maximum number of branches a pattern resolves
+	// to. 0 means no limit.
+	MaxPatternMatches int
+}
+
+// PlatformConfig maps git hosts to their platform.
+type PlatformConfig struct {
+	// Host is the hostname of the git server or a glob matching hostnames.
+	Host string
+
+	// Type is the platform of the git server: github, gitlab, bitbucket or gitea.
+	Type string
+
+	// APIEndpoint is the API endpoint of the git server, with a trailing
+	// slash. Empty means the usual endpoint of the platform on the host.
+	APIEndpoint string
+
+	// GitHubAppSecret is the secret in the MintMaker namespace holding the
+	// GitHub App of a GitHub Enterprise Server host. Empty means the App of
+	// github.com.
+	GitHubAppSecret string
+}
+
 // Config holds all controller configuration.
 type Config struct {
-	GitHub GitHubConfig
-	Kite   KiteConfig
+	GitHub     GitHubConfig
+	Kite       KiteConfig
+	Scheduling SchedulingConfig
+	Retry      RetryConfig
+	Branches   BranchesConfig
+	Platforms  []PlatformConfig
 }
 
 // fileConfig represents the JSON structure of the config file.
@@ -115,6 +259,24 @@ type fileConfig struct {
 		Enabled bool   `json:"enabled"`
 		APIURL  string `json:"api-url"`
 	} `json:"kite"`
+	Scheduling struct {
+		MaxConcurrentPipelineRuns int `json:"max-concurrent-pipelineruns"`
+		TargetBatchSize           int `json:"target-batch-size"`
+		TargetWorkers             int `json:"target-workers"`
+	} `json:"scheduling"`
+	Retry struct {
+		MaxAttempts int    `json:"max-attempts"`
+		Backoff     string `json:"backoff"`
+	} `json:"retry"`
+	Branches struct {
+		MaxPatternMatches *int `json:"max-pattern-matches"`
+	} `json:"branches"`
+	Platforms []struct {
+		Host            string `json:"host"`
+		Type            string `json:"type"`
+		APIEndpoint     string `json:"api-endpoint"`
+		GitHubAppSecret string `json:"github-app-secret"`
+	} `json:"platforms"`
 }
 
 var (
@@ -133,6 +295,17 @@ func defaultConfig() *Config {
 			Enabled: false,
 			APIURL:  os.Getenv("KITE_API_URL"),
 		},
+		Scheduling: SchedulingConfig{
+			TargetBatchSize: defaultTargetBatchSize,
+			TargetWorkers:   defaultTargetWorkers,
+		},
+		Retry: RetryConfig{
+			MaxAttempts: defaultRetryMaxAttempts,
+			Backoff:     defaultRetryBackoff,
+		},
+		Branches: BranchesConfig{
+			MaxPatternMatches: defaultMaxPatternMatches,
+		},
 	}
 }
 
@@ -179,6 +352,48 @@ func parse(data []byte, log logr.Logger) *Config {
 		cfg.Kite.APIURL = fc.Kite.APIURL
 	}
 
+	// Scheduling config
+	if fc.Scheduling.MaxConcurrentPipelineRuns > 0 {
+		cfg.Scheduling.MaxConcurrentPipelineRuns = fc.Scheduling.MaxConcurrentPipelineRuns
+	}
+	if fc.Scheduling.TargetBatchSize > 0 {
+		cfg.Scheduling.TargetBatchSize = fc.Scheduling.TargetBatchSize
+	}
+	if fc.Scheduling.TargetWorkers > 0 {
+		cfg.Scheduling.TargetWorkers = fc.Scheduling.TargetWorkers
+	}
+
+	// Retry config
+	if fc.Retry.MaxAttempts > 0 {
+		cfg.Retry.MaxAttempts = fc.Retry.MaxAttempts
+	}
+	if backoff, err := time.ParseDuration(fc.Retry.Backoff); err == nil && backoff > 0 {
+		cfg.Retry.Backoff = backoff
+	}
+
+	// Branches config, 0 disables the limit
+	if fc.Branches.MaxPatternMatches != nil && *fc.Branches.MaxPatternMatches >= 0 {
+		cfg.Branches.MaxPatternMatches = *fc.Branches.MaxPatternMatches
+	}
+
+	// Platforms config, entries without host or of unknown type are ignored
+	for _, platform := range fc.Platforms {
+		if platform.Host == "" || !slices.Contains(platformTypes, strings.ToLower(platform.Type)) {
+			log.Info("ignoring invalid platform", "host", platform.Host, "type", platform.Type)
+			continue
+		}
+		endpoint := platform.APIEndpoint
+		if endpoint != "" && !strings.HasSuffix(endpoint, "/") {
+			endpoint += "/"
+		}
+		cfg.Platforms = append(cfg.Platforms, PlatformConfig{
+			Host:            strings.ToLower(platform.Host),
+			Type:            strings.ToLower(platform.Type),
+			APIEndpoint:     endpoint,
+			GitHubAppSecret: platform.GitHubAppSecret,
+		})
+	}
+
 	if err := cfg.validate(log); err != nil {
 		return defaultConfig()
 	}
@@ -196,6 +411,25 @@ func (c *Config) validate(log logr.Logger) error {
 	return nil
 }
 
+// PlatformForHost returns the first configured platform whose host or glob
+// matches the host.
+func (c *Config) PlatformForHost(host string) (PlatformConfig, bool) {
+	host = strings.ToLower(host)
+	for _, platform := range c.Platforms {
+		if matched, _ := path.Match(platform.Host, host); matched {
+			return platform, true
+		}
+	}
+	return PlatformConfig{}, false
+}
+
+// APIEndpoint returns the configured API endpoint of the host, or an empty
+// string if there is none.
+func (c *Config) APIEndpoint(host string) string {
+	platform, _ := c.PlatformForHost(host)
+	return platform.APIEndpoint
+}
+
 var errInvalidConfig = &configError{"invalid configuration"}
 
 type configError struct {
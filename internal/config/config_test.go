@@ -0,0 +1,60 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+)
+
+func TestPlatformForHost(t *testing.T) {
+	cfg := parse([]byte(`{
+		"platforms": [
+			{"host": "git.corp.example.com", "type": "GitLab", "api-endpoint": "https://git.corp.example.com/api/v4"},
+			{"host": "code.example.com", "type": "github"},
+			{"host": "*.gitlab.example.com", "type": "github"},
+			{"host": "*.example.com", "type": "gitea"},
+			{"host": "svn.example.org", "type": "subversion"},
+			{"type": "gitlab"}
+		]
+	}`), logr.Discard())
+
+	if len(cfg.Platforms) != 4 {
+		t.Fatalf("expected invalid platforms to be ignored, got %v", cfg.Platforms)
+	}
+
+	tests := []struct {
+		host     string
+		platform string
+		endpoint string
+	}{
+		{"git.corp.example.com", "gitlab", "https://git.corp.example.com/api/v4/"},
+		{"Code.Example.com", "github", ""},
+		{"github-mirror.gitlab.example.com", "github", ""},
+		{"forge.example.com", "gitea", ""},
+		{"github.com", "", ""},
+		{"svn.example.org", "", ""},
+	}
+	for _, tt := range tests {
+		platform, ok := cfg.PlatformForHost(tt.host)
+		if ok != (tt.platform != "") || platform.Type != tt.platform {
+			t.Errorf("PlatformForHost(%q) = %q, %v, want %q", tt.host, platform.Type, ok, tt.platform)
+		}
+		if endpoint := cfg.APIEndpoint(tt.host); endpoint != tt.endpoint {
+			t.Errorf("APIEndpoint(%q) = %q, want %q", tt.host, endpoint, tt.endpoint)
+		}
+	}
+}
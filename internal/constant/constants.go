@@ -19,8 +19,30 @@ const (
 	MintMakerNamespaceName = "mintmaker"
 	// Mintmaker will add processed annotation when the dependencyupdatecheck is processed by controller
 	MintMakerProcessedAnnotationName = "mintmaker.appstudio.redhat.com/processed"
-	// Mintmaker can be disabled by disabled annotation in component
+	// Mintmaker can be disabled by disabled annotation in component, application or namespace
 	MintMakerDisabledAnnotationName = "mintmaker.appstudio.redhat.com/disabled"
+	// Mintmaker can be enabled again for a component or an application by enabled annotation,
+	// when it's disabled for its application or namespace
+	MintMakerEnabledAnnotationName = "mintmaker.appstudio.redhat.com/enabled"
+	// Label set on DependencyUpdateChecks created by a DependencyUpdateSchedule, the value is the schedule name
+	MintMakerScheduleLabelName = "mintmaker.appstudio.redhat.com/dependency-update-schedule"
+	// Annotation set on DependencyUpdateChecks created by a DependencyUpdateSchedule, the value is the scheduled time
+	MintMakerScheduledAtAnnotationName = "mintmaker.appstudio.redhat.com/scheduled-at"
+	// Finalizer of DependencyUpdateChecks which cancel their PipelineRuns when deleted
+	MintMakerCleanupFinalizerName = "mintmaker.appstudio.redhat.com/cleanup"
+	// Labels set on PipelineRuns, the values are the name and UID of the DependencyUpdateCheck which created them
+	MintMakerCheckLabelName    = "mintmaker.appstudio.redhat.com/dependency-update-check"
+	MintMakerCheckUIDLabelName = "mintmaker.appstudio.redhat.com/dependency-update-check-uid"
+	// Annotation which sets the update mode of a component, e.g. VulnerabilityOnly
+	MintMakerUpdateModeAnnotationName = "mintmaker.appstudio.redhat.com/update-mode"
+	// Label set on PipelineRuns, the value is the update mode of the scan
+	MintMakerUpdateModeLabelName = "mintmaker.appstudio.redhat.com/update-mode"
+	// Annotation which sets how often a component is scanned, daily, weekly, monthly or a cron expression
+	MintMakerScheduleAnnotationName = "mintmaker.appstudio.redhat.com/schedule"
+	// Annotation set on PipelineRuns, the value is the git URL of the scanned repository
+	MintMakerGitURLAnnotationName = "mintmaker.appstudio.redhat.com/git-url"
+	// Annotation set on PipelineRuns, the value is the scanned branch, which the branch label may truncate
+	MintMakerBranchAnnotationName = "mintmaker.appstudio.redhat.com/branch"
 	// Label for the Kite token secret, used to find the secret in the namespace
 	KiteTokenSecretLabel = "mintmaker.appstudio.redhat.com/kite-token"
 
@@ -16,55 +16,166 @@ package controller
 
 import (
 	"context"
+	"fmt"
+	"path"
 
 	appstudiov1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
 	mmv1alpha1 "github.com/konflux-ci/mintmaker/api/v1alpha1"
+	"github.com/konflux-ci/mintmaker/internal/component"
+	. "github.com/konflux-ci/mintmaker/internal/constant"
+	"github.com/konflux-ci/mintmaker/internal/utils"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-// Get only components that match a given namespace/application/componentname
-func getFilteredComponents(ctx context.Context, namespaces []mmv1alpha1.NamespaceSpec, apiClient client.Client) ([]appstudiov1alpha1.Component, error) {
-	components := []appstudiov1alpha1.Component{}
-	err := error(nil)
+// getExcludeRule returns a description of the first rule in exclude which matches
+// the component, or an empty string if the component is not excluded
+func getExcludeRule(comp *appstudiov1alpha1.Component, exclude *mmv1alpha1.ExcludeSpec) string {
+	if exclude == nil {
+		return ""
+	}
 
-	// Iterate namespaces and create query filtered by namespace
-	for _, namespace := range namespaces {
-		namespaceComponentList := &appstudiov1alpha1.ComponentList{}
-		listOps := &client.ListOptions{
-			Namespace: namespace.Namespace,
+	for _, namespace := range exclude.Namespaces {
+		if namespace == comp.Namespace {
+			return fmt.Sprintf("namespace %s", namespace)
 		}
-		if err := apiClient.List(ctx, namespaceComponentList, listOps); err != nil {
-			return nil, err
+	}
+	for _, application := range exclude.Applications {
+		if application.Application == comp.Spec.Application && (application.Namespace == "" || application.Namespace == comp.Namespace) {
+			return fmt.Sprintf("application %s", application.Application)
 		}
-		// No applications specified -> add all Namespace components, start processing next namespace
-		if len(namespace.Applications) == 0 {
-			components = append(components, namespaceComponentList.Items...)
-			continue
+	}
+	for _, excludedComponent := range exclude.Components {
+		if string(excludedComponent.Component) == comp.Name && (excludedComponent.Namespace == "" || excludedComponent.Namespace == comp.Namespace) {
+			return fmt.Sprintf("component %s", excludedComponent.Component)
 		}
-		// Applications specified -> iterate and filter by application
-		for _, application := range namespace.Applications {
-			appMatchingComponents := []appstudiov1alpha1.Component{}
-			for _, component := range namespaceComponentList.Items {
-				if application.Application == component.Spec.Application {
-					appMatchingComponents = append(appMatchingComponents, component)
-				}
-			}
-			// No components specified for an application -> add all application components, start processing next application
-			if len(application.Components) == 0 {
-				components = append(components, appMatchingComponents...)
-				continue
+	}
+
+	if len(exclude.Repositories) > 0 {
+		gitURL, _, err := component.GetGitURL(comp)
+		if err != nil {
+			// Components without a git URL are reported when they are processed
+			return ""
+		}
+		return getRepositoryExcludeRule(gitURL, exclude)
+	}
+	return ""
+}
+
+// mintMakerOptIn returns whether the annotations enable or disable MintMaker,
+// and false as second value if they don't decide it. The disabled annotation
+// wins over the enabled one.
+func mintMakerOptIn(annotations map[string]string) (bool, bool) {
+	if annotations[MintMakerDisabledAnnotationName] == "true" {
+		return false, true
+	}
+	if annotations[MintMakerEnabledAnnotationName] == "true" || annotations[MintMakerDisabledAnnotationName] == "false" {
+		return true, true
+	}
+	return false, false
+}
+
+// optOutResolver decides whether MintMaker is disabled for components by the
+// annotations of the component, its application or its namespace. The
+// annotations of applications and namespaces are looked up once per resolver.
+type optOutResolver struct {
+	apiClient    client.Client
+	applications map[string]map[string]string
+	namespaces   map[string]map[string]string
+}
+
+func newOptOutResolver(apiClient client.Client) *optOutResolver {
+	return &optOutResolver{
+		apiClient:    apiClient,
+		applications: map[string]map[string]string{},
+		namespaces:   map[string]map[string]string{},
+	}
+}
+
+// disabledBy returns the skip reason and a description of the object which
+// disables MintMaker for the component, or empty strings if it's enabled. The
+// closest object deciding it wins, so an enabled component of a disabled
+// application is processed.
+func (r *optOutResolver) disabledBy(ctx context.Context, comp *appstudiov1alpha1.Component) (string, string, error) {
+	if enabled, decided := mintMakerOptIn(comp.Annotations); decided {
+		if enabled {
+			return "", "", nil
+		}
+		return mmv1alpha1.TargetReasonDisabled, fmt.Sprintf("Component %s", comp.Name), nil
+	}
+
+	if comp.Spec.Application != "" {
+		key := comp.Namespace + "/" + comp.Spec.Application
+		annotations, ok := r.applications[key]
+		if !ok {
+			application := &appstudiov1alpha1.Application{}
+			err := r.apiClient.Get(ctx, types.NamespacedName{Namespace: comp.Namespace, Name: comp.Spec.Application}, application)
+			if err != nil && !errors.IsNotFound(err) {
+				return "", "", err
 			}
-			// Components specified -> add components with matching names
-			for _, filterComponent := range application.Components {
-				for _, component := range appMatchingComponents {
-					if filterComponent == mmv1alpha1.Component(component.Name) {
-						components = append(components, component)
-						break
-					}
-				}
+			annotations = application.Annotations
+			r.applications[key] = annotations
+		}
+		if enabled, decided := mintMakerOptIn(annotations); decided {
+			if enabled {
+				return "", "", nil
 			}
+			return mmv1alpha1.TargetReasonApplicationDisabled, fmt.Sprintf("Application %s", comp.Spec.Application), nil
+		}
+	}
+
+	annotations, ok := r.namespaces[comp.Namespace]
+	if !ok {
+		namespace := &corev1.Namespace{}
+		err := r.apiClient.Get(ctx, types.NamespacedName{Name: comp.Namespace}, namespace)
+		if err != nil && !errors.IsNotFound(err) {
+			return "", "", err
+		}
+		annotations = namespace.Annotations
+		r.namespaces[comp.Namespace] = annotations
+	}
+	if enabled, decided := mintMakerOptIn(annotations); decided && !enabled {
+		return mmv1alpha1.TargetReasonNamespaceDisabled, fmt.Sprintf("Namespace %s", comp.Namespace), nil
+	}
+	return "", "", nil
+}
+
+// getRepositoryExcludeRule returns the first repository pattern in exclude
+// which matches the host and repository of the git URL, or an empty string if
+// there is none. The repository is the one of the git component, so the
+// patterns don't depend on the shape of the URL.
+func getRepositoryExcludeRule(gitURL string, exclude *mmv1alpha1.ExcludeSpec) string {
+	if exclude == nil {
+		return ""
+	}
+	host, err := utils.GetGitHost(gitURL)
+	if err != nil {
+		return ""
+	}
+	repository, err := component.GetRepository(gitURL)
+	if err != nil {
+		return ""
+	}
+	for _, pattern := range exclude.Repositories {
+		// Invalid patterns never match
+		if matched, _ := path.Match(pattern, host+"/"+repository); matched {
+			return fmt.Sprintf("repository %s", pattern)
 		}
 	}
+	return ""
+}
 
-	return components, err
+// getUpdateMode returns the update mode of a component scanned by the check. The
+// component can switch itself to the vulnerability-only mode by annotation, even
+// if the check proposes all updates. Repositories without a component are passed as nil.
+func getUpdateMode(check *mmv1alpha1.DependencyUpdateCheck, comp *appstudiov1alpha1.Component) mmv1alpha1.UpdateMode {
+	if comp != nil && comp.Annotations[MintMakerUpdateModeAnnotationName] == string(mmv1alpha1.UpdateModeVulnerabilityOnly) {
+		return mmv1alpha1.UpdateModeVulnerabilityOnly
+	}
+	if check.Spec.UpdateMode == "" {
+		return mmv1alpha1.UpdateModeAll
+	}
+	return check.Spec.UpdateMode
 }
@@ -16,23 +16,33 @@ package controller
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	goerrors "errors"
 	"fmt"
-	"slices"
+	"hash/fnv"
+	"sync"
 	"time"
 
+	"github.com/go-logr/logr"
+	"github.com/robfig/cron/v3"
+
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 
@@ -40,19 +50,48 @@ import (
 
 	mmv1alpha1 "github.com/konflux-ci/mintmaker/api/v1alpha1"
 	"github.com/konflux-ci/mintmaker/internal/component"
+	"github.com/konflux-ci/mintmaker/internal/component/base"
+	ghcomponent "github.com/konflux-ci/mintmaker/internal/component/github"
 	"github.com/konflux-ci/mintmaker/internal/config"
 	. "github.com/konflux-ci/mintmaker/internal/constant"
 	mintmakermetrics "github.com/konflux-ci/mintmaker/internal/metrics"
+	"github.com/konflux-ci/mintmaker/internal/selection"
 	"github.com/konflux-ci/mintmaker/internal/tekton"
 	"github.com/konflux-ci/mintmaker/internal/utils"
 )
 
 const InternalSecretLabelName = "appstudio.redhat.com/internal"
 
+// queuedTargetsRecheckInterval is the interval in which checks with queued
+// targets are reconciled, in case the release of the queue was missed
+const queuedTargetsRecheckInterval = time.Minute
+
+// pendingTargetsBatchInterval is the delay between the batches of pending
+// targets of a check, so large checks don't starve the other checks
+const pendingTargetsBatchInterval = time.Second
+
+// maxSkippedTargets is the number of skipped targets kept in the status of a
+// check, the others are only counted, see compactTargets
+const maxSkippedTargets = 1000
+
 // DependencyUpdateCheckReconciler reconciles a DependencyUpdateCheck object
 type DependencyUpdateCheckReconciler struct {
 	Client client.Client
 	Scheme *runtime.Scheme
+	// MaxConcurrentPipelineRuns limits the number of unfinished PipelineRuns
+	// in the MintMaker namespace, targets beyond it are queued. 0 means no limit.
+	MaxConcurrentPipelineRuns int
+	// TargetBatchSize is the number of pending targets processed per
+	// reconcile, the progress is stored after every batch. 0 means all.
+	TargetBatchSize int
+	// TargetWorkers is the number of pending targets of a batch whose
+	// branches are looked up in parallel
+	TargetWorkers int
+	// QueueReleases receives checks with queued targets once running
+	// PipelineRuns finish, see PipelineRunReconciler
+	QueueReleases <-chan event.GenericEvent
+
+	createdPipelineRuns createdPipelineRuns
 }
 
 func NewDependencyUpdateCheckReconciler(client client.Client, scheme *runtime.Scheme, eventRecorder record.EventRecorder) *DependencyUpdateCheckReconciler {
@@ -62,6 +101,93 @@ func NewDependencyUpdateCheckReconciler(client client.Client, scheme *runtime.Sc
 	}
 }
 
+// pipelineRunCapacity returns how many PipelineRuns can be created before the
+// limit of concurrent PipelineRuns is reached, or -1 if there is no limit
+func (r *DependencyUpdateCheckReconciler) pipelineRunCapacity(ctx context.Context) (int, error) {
+	if r.MaxConcurrentPipelineRuns <= 0 {
+		return -1, nil
+	}
+
+	pipelineRuns := &tektonv1.PipelineRunList{}
+	if err := r.Client.List(ctx, pipelineRuns, client.InNamespace(MintMakerNamespaceName)); err != nil {
+		return 0, err
+	}
+	active := 0
+	listed := make(map[string]struct{}, len(pipelineRuns.Items))
+	for _, plr := range pipelineRuns.Items {
+		listed[plr.Name] = struct{}{}
+		if !plr.IsDone() {
+			active++
+		}
+	}
+	// PipelineRuns created by earlier batches may not be in the cache yet
+	active += r.createdPipelineRuns.unlisted(listed)
+	return max(r.MaxConcurrentPipelineRuns-active, 0), nil
+}
+
+// createdPipelineRunsTTL is how long a created PipelineRun is counted as
+// running while it's missing from the cache
+const createdPipelineRunsTTL = time.Minute
+
+// createdPipelineRuns remembers the PipelineRuns created by the controller
+// until the cache has them, so they count against the concurrency limit
+type createdPipelineRuns struct {
+	mutex sync.Mutex
+	names map[string]time.Time
+}
+
+// add records the creation of the PipelineRun
+func (c *createdPipelineRuns) add(name string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.names == nil {
+		c.names = map[string]time.Time{}
+	}
+	c.names[name] = time.Now()
+}
+
+// unlisted returns the number of recently created PipelineRuns which aren't
+// listed yet, and forgets the listed and expired ones
+func (c *createdPipelineRuns) unlisted(listed map[string]struct{}) int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	count := 0
+	for name, created := range c.names {
+		if _, ok := listed[name]; ok || time.Since(created) > createdPipelineRunsTTL {
+			delete(c.names, name)
+			continue
+		}
+		count++
+	}
+	return count
+}
+
+// getKiteSecretName returns the name of the Kite token Secret, or an empty
+// string if Kite integration is disabled or the Secret isn't found
+func (r *DependencyUpdateCheckReconciler) getKiteSecretName(ctx context.Context) string {
+	log := ctrllog.FromContext(ctx)
+
+	// Token needed for Kite API requests
+	if cfg := config.Get(); !cfg.Kite.Enabled {
+		return ""
+	}
+	secretList := &corev1.SecretList{}
+	err := r.Client.List(ctx, secretList,
+		client.InNamespace(MintMakerNamespaceName),
+		client.MatchingLabels{KiteTokenSecretLabel: "true"},
+	)
+	if err != nil {
+		log.Error(err, "Kite token secret lookup failed - skipping Kite integration")
+		return ""
+	}
+	if len(secretList.Items) == 0 {
+		log.Info("Kite token secret not found - skipping Kite integration")
+		return ""
+	}
+	log.Info("Kite token secret found - using it", "secretName", secretList.Items[0].Name)
+	return secretList.Items[0].Name
+}
+
 // getCAConfigMap returns the first ConfigMap found in mintmaker namespace
 // that has the label 'config.openshift.io/inject-trusted-cabundle: "true"'.
 // If no such ConfigMap is found, it returns nil.
@@ -156,22 +282,11 @@ func (r *DependencyUpdateCheckReconciler) getMergedDockerConfigJson(ctx context.
 	return mergedDockerConfigJson, nil
 }
 
-// createPipelineRun creates and returns a new PipelineRun
-func (r *DependencyUpdateCheckReconciler) createPipelineRun(ctx context.Context, name string, comp component.GitComponent, currentBranch string, kiteSecretName string) (*tektonv1.PipelineRun, error) {
+// buildRenovateSecret returns the Secret with the Renovate token (repository access token)
+// and image registry credentials for the component, without creating it
+func (r *DependencyUpdateCheckReconciler) buildRenovateSecret(ctx context.Context, name string, comp component.GitComponent) (*corev1.Secret, error) {
+	log := ctrllog.FromContext(ctx).WithName("buildRenovateSecret")
 
-	log := ctrllog.FromContext(ctx).WithName("createPipelineRun")
-
-	var resources []client.Object
-	defer func() {
-		if len(resources) > 0 {
-			for _, resource := range resources {
-				// Ignore error
-				r.Client.Delete(ctx, resource)
-			}
-		}
-	}()
-
-	// Secret for Renovate token (repository access token) and image registry credentials
 	renovateSecret := &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name,
@@ -206,7 +321,52 @@ func (r *DependencyUpdateCheckReconciler) createPipelineRun(ctx context.Context,
 		renovateSecret.Data = map[string][]byte{corev1.DockerConfigJsonKey: mergedDockerConfigJson}
 	}
 
-	if err := r.Client.Create(ctx, renovateSecret); err != nil {
+	return renovateSecret, nil
+}
+
+// planTarget does every lookup needed to create a PipelineRun for the
+// branch of the component, but doesn't create any resources
+func (r *DependencyUpdateCheckReconciler) planTarget(ctx context.Context, comp component.GitComponent, currentBranch string) error {
+	renovateSecret, err := r.buildRenovateSecret(ctx, "dry-run", comp)
+	if err != nil {
+		return err
+	}
+
+	// GitHub tokens are only generated once the PipelineRun pod is started,
+	// make sure that it would be possible
+	if comp.GetPlatform() == "github" {
+		if _, err := comp.GetToken(); err != nil {
+			return err
+		}
+	}
+
+	if _, err := comp.GetRenovateConfig(renovateSecret, currentBranch); err != nil {
+		return err
+	}
+	return nil
+}
+
+// createPipelineRun creates and returns a new PipelineRun
+func (r *DependencyUpdateCheckReconciler) createPipelineRun(ctx context.Context, run *checkRun, name string, comp component.GitComponent, currentBranch string, updateMode mmv1alpha1.UpdateMode) (*tektonv1.PipelineRun, error) {
+
+	log := ctrllog.FromContext(ctx).WithName("createPipelineRun")
+
+	var resources []client.Object
+	defer func() {
+		if len(resources) > 0 {
+			for _, resource := range resources {
+				// Ignore error
+				r.Client.Delete(ctx, resource)
+			}
+		}
+	}()
+
+	renovateSecret, err := r.buildRenovateSecret(ctx, name, comp)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.createOrUpdate(ctx, renovateSecret); err != nil {
 		return nil, err
 	}
 	resources = append(resources, renovateSecret)
@@ -227,7 +387,7 @@ func (r *DependencyUpdateCheckReconciler) createPipelineRun(ctx context.Context,
 		},
 	}
 
-	if err := r.Client.Create(ctx, renovateConfigMap); err != nil {
+	if err := r.createOrUpdate(ctx, renovateConfigMap); err != nil {
 		return nil, err
 	}
 	resources = append(resources, renovateConfigMap)
@@ -250,7 +410,7 @@ func (r *DependencyUpdateCheckReconciler) createPipelineRun(ctx context.Context,
 			},
 		}
 
-		if err := r.Client.Create(ctx, rpmSecret); err != nil {
+		if err := r.createOrUpdate(ctx, rpmSecret); err != nil {
 			return nil, err
 		}
 		resources = append(resources, rpmSecret)
@@ -262,10 +422,17 @@ func (r *DependencyUpdateCheckReconciler) createPipelineRun(ctx context.Context,
 			"mintmaker.appstudio.redhat.com/application":  comp.GetApplication(),
 			"mintmaker.appstudio.redhat.com/component":    comp.GetName(),
 			"mintmaker.appstudio.redhat.com/namespace":    comp.GetNamespace(),
-			"mintmaker.appstudio.redhat.com/git-platform": comp.GetPlatform(), // (github, gitlab)
+			"mintmaker.appstudio.redhat.com/git-platform": comp.GetPlatform(), // (github, gitlab, bitbucket, gitea)
 			"mintmaker.appstudio.redhat.com/git-host":     comp.GetHost(),     // github.com, gitlab.com, gitlab.other.com
 			"mintmaker.appstudio.redhat.com/repository":   utils.NormalizeLabelValue(comp.GetRepository()),
 			"mintmaker.appstudio.redhat.com/branch":       utils.NormalizeLabelValue(currentBranch),
+			MintMakerUpdateModeLabelName:                  string(updateMode),
+			MintMakerCheckLabelName:                       run.check.Name,
+			MintMakerCheckUIDLabelName:                    string(run.check.UID),
+		}).
+		WithAnnotations(map[string]string{
+			MintMakerGitURLAnnotationName: comp.GetGitURL(),
+			MintMakerBranchAnnotationName: currentBranch,
 		}).
 		WithTimeouts(nil)
 	builder.WithServiceAccount("mintmaker-controller-manager")
@@ -333,7 +500,7 @@ func (r *DependencyUpdateCheckReconciler) createPipelineRun(ctx context.Context,
 	}
 
 	// Add Kite integration if enabled AND token is available
-	if kiteSecretName != "" {
+	if run.kiteSecretName != "" {
 		builder.WithKiteIntegration(config.Get().Kite.APIURL)
 		opts := tekton.NewMountOptions().
 			WithTaskName("build").
@@ -358,7 +525,7 @@ func (r *DependencyUpdateCheckReconciler) createPipelineRun(ctx context.Context,
 			WithTaskName("build").
 			WithStepNames([]string{"log-analyzer"}).
 			WithReadOnly(true)
-		builder.WithSecret(kiteSecretName, "/var/run/secrets/kite", tokenSecretItems, tokenSecretOpts)
+		builder.WithSecret(run.kiteSecretName, "/var/run/secrets/kite", tokenSecretItems, tokenSecretOpts)
 	}
 
 	pipelineRun, err := builder.Build()
@@ -366,10 +533,28 @@ func (r *DependencyUpdateCheckReconciler) createPipelineRun(ctx context.Context,
 		log.Error(err, "failed to build pipeline definition")
 		return nil, err
 	}
+	// PipelineRuns of checks which opted in to the cleanup are garbage-collected
+	// with the check, their resources are owned by the PipelineRun
+	if run.check.Spec.CleanupOnDelete {
+		if err := controllerutil.SetOwnerReference(run.check, pipelineRun, r.Scheme); err != nil {
+			return nil, err
+		}
+	}
 	if err := r.Client.Create(ctx, pipelineRun); err != nil {
-		return nil, err
+		if !errors.IsAlreadyExists(err) {
+			return nil, err
+		}
+		// Created concurrently or by an interrupted attempt for the same
+		// target, its resources are the ones just written, so they must be
+		// kept and owned by it
+		log.Info("PipelineRun already exists", "pipelineRun", name)
+		resources = nil
+		if err := r.Client.Get(ctx, client.ObjectKeyFromObject(pipelineRun), pipelineRun); err != nil {
+			return nil, err
+		}
+	} else {
+		resources = append(resources, pipelineRun)
 	}
-	resources = append(resources, pipelineRun)
 
 	// Set ownership so all resources get deleted once the job is deleted
 	// ownership for renovateSecret
@@ -402,10 +587,108 @@ func (r *DependencyUpdateCheckReconciler) createPipelineRun(ctx context.Context,
 	return pipelineRun, nil
 }
 
+// getRunningPipelineRun returns the name of a PipelineRun which hasn't finished
+// yet for the branch of the component, or an empty string if there is none
+func (r *DependencyUpdateCheckReconciler) getRunningPipelineRun(ctx context.Context, comp component.GitComponent, branch string) (string, error) {
+	pipelineRuns := &tektonv1.PipelineRunList{}
+	if err := r.Client.List(ctx, pipelineRuns, client.InNamespace(MintMakerNamespaceName),
+		client.MatchingLabels{
+			"mintmaker.appstudio.redhat.com/git-host":   comp.GetHost(),
+			"mintmaker.appstudio.redhat.com/repository": utils.NormalizeLabelValue(comp.GetRepository()),
+			"mintmaker.appstudio.redhat.com/branch":     utils.NormalizeLabelValue(branch),
+		}); err != nil {
+		return "", err
+	}
+
+	for _, plr := range pipelineRuns.Items {
+		if plr.IsDone() {
+			continue
+		}
+		// Label values are truncated, the annotations have the whole URL and branch
+		if gitURL, ok := plr.Annotations[MintMakerGitURLAnnotationName]; ok && gitURL != comp.GetGitURL() {
+			continue
+		}
+		if plrBranch, ok := plr.Annotations[MintMakerBranchAnnotationName]; ok && plrBranch != branch {
+			continue
+		}
+		return plr.Name, nil
+	}
+	return "", nil
+}
+
+// createOrUpdate creates the object, or updates it if it's left over from an
+// interrupted attempt to create the same PipelineRun. The metadata and data keys
+// of the existing object are kept, it may already be owned by the PipelineRun
+// and its secret may hold the token added by the event controller.
+func (r *DependencyUpdateCheckReconciler) createOrUpdate(ctx context.Context, obj client.Object) error {
+	err := r.Client.Create(ctx, obj)
+	if !errors.IsAlreadyExists(err) {
+		return err
+	}
+	existing := obj.DeepCopyObject().(client.Object)
+	if err := r.Client.Get(ctx, client.ObjectKeyFromObject(obj), existing); err != nil {
+		return err
+	}
+
+	obj.SetLabels(mergeMaps(existing.GetLabels(), obj.GetLabels()))
+	obj.SetAnnotations(mergeMaps(existing.GetAnnotations(), obj.GetAnnotations()))
+	// The owner references are only set once the PipelineRun is created
+	obj.SetOwnerReferences(existing.GetOwnerReferences())
+	switch o := obj.(type) {
+	case *corev1.Secret:
+		o.Data = mergeMaps(existing.(*corev1.Secret).Data, o.Data)
+	case *corev1.ConfigMap:
+		o.Data = mergeMaps(existing.(*corev1.ConfigMap).Data, o.Data)
+	}
+	obj.SetResourceVersion(existing.GetResourceVersion())
+	return r.Client.Update(ctx, obj)
+}
+
+// mergeMaps returns the entries of both maps, the ones of overrides win
+func mergeMaps[V any](base, overrides map[string]V) map[string]V {
+	if len(base) == 0 {
+		return overrides
+	}
+	merged := make(map[string]V, len(base)+len(overrides))
+	for key, value := range base {
+		merged[key] = value
+	}
+	for key, value := range overrides {
+		merged[key] = value
+	}
+	return merged
+}
+
+// cancelPipelineRuns cancels the PipelineRuns created by the check which haven't finished yet
+func (r *DependencyUpdateCheckReconciler) cancelPipelineRuns(ctx context.Context, check *mmv1alpha1.DependencyUpdateCheck) error {
+	log := ctrllog.FromContext(ctx)
+
+	pipelineRuns := &tektonv1.PipelineRunList{}
+	if err := r.Client.List(ctx, pipelineRuns, client.InNamespace(MintMakerNamespaceName),
+		client.MatchingLabels{MintMakerCheckUIDLabelName: string(check.UID)}); err != nil {
+		return err
+	}
+
+	for _, plr := range pipelineRuns.Items {
+		if plr.IsDone() || plr.IsCancelled() {
+			continue
+		}
+		original := plr.DeepCopy()
+		plr.Spec.Status = tektonv1.PipelineRunSpecStatusCancelled
+		if err := r.Client.Patch(ctx, &plr, client.MergeFrom(original)); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+		log.Info("cancelled PipelineRun of deleted DependencyUpdateCheck", "pipelineRun", plr.Name)
+	}
+	return nil
+}
+
 // +kubebuilder:rbac:groups=appstudio.redhat.com,resources=dependencyupdatechecks,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=appstudio.redhat.com,resources=dependencyupdatechecks/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=appstudio.redhat.com,resources=dependencyupdatechecks/finalizers,verbs=update
 // +kubebuilder:rbac:groups=appstudio.redhat.com,resources=components,verbs=get;list;watch
+// +kubebuilder:rbac:groups=appstudio.redhat.com,resources=applications,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
 // +kubebuilder:rbac:groups=tekton.dev,resources=pipelineruns,verbs=get;list;watch;create;update;patch
 // +kubebuilder:rbac:groups=tekton.dev,resources=pipelineruns/finalizers,verbs=update
 // +kubebuilder:rbac:groups=tekton.dev,resources=pipelineruns/status,verbs=get;update;patch
@@ -429,152 +712,701 @@ func (r *DependencyUpdateCheckReconciler) Reconcile(ctx context.Context, req ctr
 	err := r.Client.Get(ctx, req.NamespacedName, dependencyupdatecheck)
 	if err != nil {
 		if errors.IsNotFound(err) {
+			mintmakermetrics.DeleteDependencyUpdateCheckQueuedTargets(req.Namespace, req.Name)
 			return ctrl.Result{}, nil
 		}
+		log.Error(err, "failed to get DependencyUpdateCheck")
+		return ctrl.Result{}, err
 	}
 
-	// If the DependencyUpdateCheck has been handled before, skip it
-	if value, exists := dependencyupdatecheck.Annotations[MintMakerProcessedAnnotationName]; exists && value == "true" {
-		log.Info(fmt.Sprintf("DependencyUpdateCheck has been processed: %v", req.NamespacedName))
+	if !dependencyupdatecheck.DeletionTimestamp.IsZero() {
+		if controllerutil.ContainsFinalizer(dependencyupdatecheck, MintMakerCleanupFinalizerName) {
+			if err := r.cancelPipelineRuns(ctx, dependencyupdatecheck); err != nil {
+				log.Error(err, "failed to cancel PipelineRuns of DependencyUpdateCheck")
+				return ctrl.Result{}, err
+			}
+			controllerutil.RemoveFinalizer(dependencyupdatecheck, MintMakerCleanupFinalizerName)
+			if err := r.Client.Update(ctx, dependencyupdatecheck); err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+		mintmakermetrics.DeleteDependencyUpdateCheckQueuedTargets(req.Namespace, req.Name)
 		return ctrl.Result{}, nil
 	}
 
-	// Update the DependencyUpdateCheck to add a processed annotation
-	log.Info(fmt.Sprintf("new DependencyUpdateCheck found: %v", req.NamespacedName))
+	// The cleanup finalizer is only set on checks which opted in
+	if dependencyupdatecheck.Spec.CleanupOnDelete != controllerutil.ContainsFinalizer(dependencyupdatecheck, MintMakerCleanupFinalizerName) {
+		if dependencyupdatecheck.Spec.CleanupOnDelete {
+			controllerutil.AddFinalizer(dependencyupdatecheck, MintMakerCleanupFinalizerName)
+		} else {
+			controllerutil.RemoveFinalizer(dependencyupdatecheck, MintMakerCleanupFinalizerName)
+		}
+		if err := r.Client.Update(ctx, dependencyupdatecheck); err != nil {
+			log.Error(err, "failed to update finalizers of DependencyUpdateCheck")
+			return ctrl.Result{}, err
+		}
+	}
 
-	// Record metrics for DependencyUpdateCheck creation
-	mintmakermetrics.RecordDependencyUpdateCheckCreation(dependencyupdatecheck.Namespace, dependencyupdatecheck.Name)
-	log.Info("Recorded DependencyUpdateCheck creation metrics", "namespace", dependencyupdatecheck.Namespace, "name", dependencyupdatecheck.Name)
+	status := &dependencyupdatecheck.Status
+	generation := dependencyupdatecheck.Generation
 
-	if dependencyupdatecheck.Annotations == nil {
-		dependencyupdatecheck.Annotations = map[string]string{}
+	// Checks processed by older versions of the controller only carry the
+	// processed annotation, adopt them without running them again
+	if value, exists := dependencyupdatecheck.Annotations[MintMakerProcessedAnnotationName]; exists && value == "true" && status.ObservedGeneration == 0 {
+		log.Info(fmt.Sprintf("DependencyUpdateCheck has been processed: %v", req.NamespacedName))
+		status.ObservedGeneration = generation
+		completeRun(status)
+		return ctrl.Result{}, r.updateStatus(ctx, dependencyupdatecheck)
 	}
-	dependencyupdatecheck.Annotations[MintMakerProcessedAnnotationName] = "true"
 
-	err = r.Client.Update(ctx, dependencyupdatecheck)
-	if err != nil {
-		log.Error(err, "failed to update DependencyUpdateCheck annotations")
+	// If the current generation has been handled before, skip it
+	if status.ObservedGeneration == generation && status.Phase != mmv1alpha1.PhaseRunning {
+		log.Info(fmt.Sprintf("DependencyUpdateCheck has been processed: %v", req.NamespacedName), "generation", generation)
 		return ctrl.Result{}, nil
 	}
 
-	var gatheredComponents []appstudiov1alpha1.Component
-	if len(dependencyupdatecheck.Spec.Namespaces) > 0 {
-		log.Info(fmt.Sprintf("Following components are specified: %v", dependencyupdatecheck.Spec.Namespaces))
-		gatheredComponents, err = getFilteredComponents(ctx, dependencyupdatecheck.Spec.Namespaces, r.Client)
+	if status.ObservedGeneration != generation {
+		log.Info(fmt.Sprintf("new DependencyUpdateCheck found: %v", req.NamespacedName), "generation", generation)
+
+		// Record metrics for DependencyUpdateCheck creation
+		mintmakermetrics.RecordDependencyUpdateCheckCreation(dependencyupdatecheck.Namespace, dependencyupdatecheck.Name)
+		log.Info("Recorded DependencyUpdateCheck creation metrics", "namespace", dependencyupdatecheck.Namespace, "name", dependencyupdatecheck.Name)
+
+		if len(dependencyupdatecheck.Spec.Namespaces) > 0 {
+			log.Info(fmt.Sprintf("Following components are specified: %v", dependencyupdatecheck.Spec.Namespaces))
+		}
+		targets, err := r.discoverTargets(ctx, dependencyupdatecheck)
 		if err != nil {
-			log.Error(err, "gathering filtered components has failed")
+			log.Error(err, "gathering components has failed")
 			return ctrl.Result{}, err
 		}
-	} else {
-		allComponents := &appstudiov1alpha1.ComponentList{}
-		if err := r.Client.List(ctx, allComponents, &client.ListOptions{}); err != nil {
-			log.Error(err, "failed to list Components")
+
+		// Persist the discovered targets, so a restarted controller resumes
+		// the run from the pending ones
+		startRun(status, generation)
+		status.Targets = targets
+		compactTargets(status)
+		updateStatusSummary(status)
+		if err := r.Client.Status().Update(ctx, dependencyupdatecheck); err != nil {
+			log.Error(err, "failed to update DependencyUpdateCheck status")
 			return ctrl.Result{}, err
 		}
-		gatheredComponents = allComponents.Items
+		log.Info(fmt.Sprintf("%d components and repositories will be processed", status.PendingTargets))
+	} else {
+		log.Info(fmt.Sprintf("resuming DependencyUpdateCheck: %v", req.NamespacedName), "generation", generation,
+			"pending", status.PendingTargets, "queued", status.QueuedTargets)
 	}
 
-	log.Info(fmt.Sprintf("%d components will be processed", len(gatheredComponents)))
+	if status.PendingTargets > 0 {
+		return r.processPendingTargets(ctx, dependencyupdatecheck)
+	}
 
-	// Filter out components which have mintmaker disabled
-	componentList := []appstudiov1alpha1.Component{}
+	// Queued targets of the current generation are scheduled as running PipelineRuns finish
+	if status.QueuedTargets > 0 {
+		return r.scheduleQueuedTargets(ctx, dependencyupdatecheck)
+	}
+
+	settleRun(status)
+	return ctrl.Result{}, r.updateStatus(ctx, dependencyupdatecheck)
+}
+
+// discoverTargets returns the targets of the check: a pending target for every
+// Component and repository to process, and a skipped target for every excluded
+// or disabled one. The branches of the pending targets are looked up later, in
+// batches, see processPendingTargets.
+func (r *DependencyUpdateCheckReconciler) discoverTargets(ctx context.Context, check *mmv1alpha1.DependencyUpdateCheck) ([]mmv1alpha1.TargetStatus, error) {
+	log := ctrllog.FromContext(ctx)
+
+	gatheredComponents, err := selection.GetCheckComponents(ctx, &check.Spec, r.Client)
+	if err != nil {
+		return nil, err
+	}
+
+	targets := []mmv1alpha1.TargetStatus{}
+	optOut := newOptOutResolver(r.Client)
+	excluded := 0
 	for _, component := range gatheredComponents {
-		if value, exists := component.Annotations[MintMakerDisabledAnnotationName]; !exists || value != "true" {
-			componentList = append(componentList, component)
+		target := newComponentTarget(&component)
+		if rule := getExcludeRule(&component, check.Spec.Exclude); rule != "" {
+			targets = append(targets, skipped(target, mmv1alpha1.TargetReasonExcluded,
+				fmt.Sprintf("excluded by rule: %s", rule)))
+			excluded++
+			continue
 		}
+		reason, disabledBy, err := optOut.disabledBy(ctx, &component)
+		if err != nil {
+			return nil, err
+		}
+		if reason != "" {
+			targets = append(targets, skipped(target, reason,
+				fmt.Sprintf("MintMaker is disabled by the %s annotation of %s", MintMakerDisabledAnnotationName, disabledBy)))
+			excluded++
+			continue
+		}
+		target.State = mmv1alpha1.TargetStatePending
+		targets = append(targets, target)
 	}
+	log.Info("found components which are excluded or have mintmaker disabled", "components", excluded)
+
+	for _, repository := range check.Spec.Repositories {
+		// Credentials of repositories without a Component are looked up in the
+		// credentials namespace, by default in the namespace of the check
+		namespace := repository.CredentialsNamespace
+		if namespace == "" {
+			namespace = check.Namespace
+		}
+		target := mmv1alpha1.TargetStatus{Namespace: namespace}
 
-	log.Info("found components with mintmaker disabled", "components", len(gatheredComponents)-len(componentList))
-	if len(componentList) == 0 {
-		return ctrl.Result{}, nil
+		if rule := getRepositoryExcludeRule(repository.URL, check.Spec.Exclude); rule != "" {
+			targets = append(targets, skipped(target, mmv1alpha1.TargetReasonExcluded,
+				fmt.Sprintf("repository %s excluded by rule: %s", repository.URL, rule)))
+			continue
+		}
+		target.URL = repository.URL
+		target.State = mmv1alpha1.TargetStatePending
+		targets = append(targets, target)
 	}
+	return targets, nil
+}
 
-	// Check for token Secret if Kite integration is enabled (token needed for Kite API requests)
-	// kiteSecretName is only set if Kite integration is enabled and the token secret is found
-	kiteSecretName := ""
-	if cfg := config.Get(); cfg.Kite.Enabled {
-		secretList := &corev1.SecretList{}
-		err := r.Client.List(ctx, secretList,
-			client.InNamespace(MintMakerNamespaceName),
-			client.MatchingLabels{KiteTokenSecretLabel: "true"},
-		)
+// processPendingTargets processes the next batch of pending targets of the
+// check. The branches of the batch are looked up by parallel workers, then the
+// branches are scheduled in the order of the targets. The progress is stored
+// after every batch and the check is requeued until no target is pending.
+func (r *DependencyUpdateCheckReconciler) processPendingTargets(ctx context.Context, check *mmv1alpha1.DependencyUpdateCheck) (ctrl.Result, error) {
+	log := ctrllog.FromContext(ctx)
+	status := &check.Status
+
+	capacity, err := r.pipelineRunCapacity(ctx)
+	if err != nil {
+		log.Error(err, "failed to count running PipelineRuns")
+		return ctrl.Result{}, err
+	}
+	run := &checkRun{
+		check:          check,
+		kiteSecretName: r.getKiteSecretName(ctx),
+		processedKeys:  sets.New[string](),
+		capacity:       capacity,
+	}
+
+	// Pick the batch and collect the repository+branch keys of previous batches
+	batch := []int{}
+	for i, target := range status.Targets {
+		if target.State == mmv1alpha1.TargetStatePending {
+			if r.TargetBatchSize <= 0 || len(batch) < r.TargetBatchSize {
+				batch = append(batch, i)
+			}
+		} else if target.Branch != "" {
+			run.processedKeys.Insert(targetKey(target))
+		}
+	}
+	log.Info("processing pending targets", "batch", len(batch), "pending", status.PendingTargets)
+
+	resolved := r.resolvePendingTargets(ctx, check, status.Targets, batch)
+
+	// The scan times are only needed for Components with a scan schedule
+	for _, pending := range resolved {
+		if pending.schedule == nil {
+			continue
+		}
+		run.scanTimes, err = r.getScanTimes(ctx)
 		if err != nil {
-			log.Error(err, "Kite token secret lookup failed - skipping Kite integration")
-		} else if len(secretList.Items) == 0 {
-			log.Info("Kite token secret not found - skipping Kite integration")
-		} else {
-			kiteSecretName = secretList.Items[0].Name
-			log.Info("Kite token secret found - using it", "secretName", kiteSecretName)
+			log.Error(err, "failed to get scan times")
+			return ctrl.Result{}, err
+		}
+		break
+	}
+
+	run.targets = make([]mmv1alpha1.TargetStatus, 0, len(status.Targets))
+	for i, target := range status.Targets {
+		pending, ok := resolved[i]
+		if !ok {
+			run.targets = append(run.targets, target)
+			continue
+		}
+		if pending.outcome != nil {
+			run.targets = append(run.targets, *pending.outcome)
+			continue
 		}
+		r.processGitComponent(ctrllog.IntoContext(ctx, pending.log), run, pending.comp, pending.target, pending.branches, pending.schedule)
 	}
 
-	// Track components for which we already created a PipelineRun
-	processedComponents := make([]string, 0)
+	status.Targets = run.targets
+	settleRun(status)
+	if err := r.updateStatus(ctx, check); err != nil {
+		return ctrl.Result{}, err
+	}
+	if err := r.recordScanTimes(ctx, run.scanned, time.Now()); err != nil {
+		log.Error(err, "failed to record scan times")
+		return ctrl.Result{}, err
+	}
+	if status.PendingTargets > 0 {
+		return ctrl.Result{RequeueAfter: pendingTargetsBatchInterval}, nil
+	}
+	return queuedResult(status), nil
+}
 
-	timestamp := time.Now().UTC().Format("01021504") // MMDDhhmm, from Go's time formatting reference date "20060102150405"
-	for _, appstudioComponent := range componentList {
-		compLog := log.WithValues("component", appstudioComponent.Name,
-			"componentNamespace", appstudioComponent.Namespace)
-		ctx = ctrllog.IntoContext(ctx, compLog)
+// resolvedTarget is a pending target with its git component and branches
+type resolvedTarget struct {
+	log      logr.Logger
+	comp     component.GitComponent
+	target   mmv1alpha1.TargetStatus
+	branches []string
+	// schedule is the scan schedule of the Component, nil if it's scanned by every check
+	schedule cron.Schedule
+	// outcome is set if the target is finished without scheduling its
+	// branches, e.g. because its Component can't be handled
+	outcome *mmv1alpha1.TargetStatus
+}
+
+// resolvePendingTargets looks up the git components and branches of the
+// targets at the given indices, by up to r.TargetWorkers targets in parallel.
+// The results are keyed by the index of the target.
+func (r *DependencyUpdateCheckReconciler) resolvePendingTargets(ctx context.Context, check *mmv1alpha1.DependencyUpdateCheck, targets []mmv1alpha1.TargetStatus, indices []int) map[int]*resolvedTarget {
+	results := make([]*resolvedTarget, len(indices))
+
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for range min(max(r.TargetWorkers, 1), len(indices)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				results[i] = r.resolvePendingTarget(ctx, check, targets[indices[i]])
+			}
+		}()
+	}
+	for i := range indices {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+
+	resolved := make(map[int]*resolvedTarget, len(indices))
+	for i, index := range indices {
+		resolved[index] = results[i]
+	}
+	return resolved
+}
+
+// resolvePendingTarget looks up the git component and branches of a pending target
+func (r *DependencyUpdateCheckReconciler) resolvePendingTarget(ctx context.Context, check *mmv1alpha1.DependencyUpdateCheck, target mmv1alpha1.TargetStatus) *resolvedTarget {
+	log := ctrllog.FromContext(ctx)
+	result := &resolvedTarget{}
+	finish := func(outcome mmv1alpha1.TargetStatus) *resolvedTarget {
+		result.outcome = &outcome
+		return result
+	}
 
-		comp, err := component.NewGitComponent(ctx, &appstudioComponent, r.Client)
+	var updateMode mmv1alpha1.UpdateMode
+	if target.Component != "" {
+		result.log = log.WithValues("component", target.Component, "componentNamespace", target.Namespace)
+		compCtx := ctrllog.IntoContext(ctx, result.log)
+
+		appstudioComponent := &appstudiov1alpha1.Component{}
+		err := r.Client.Get(compCtx, types.NamespacedName{Namespace: target.Namespace, Name: target.Component}, appstudioComponent)
+		if err == nil {
+			result.comp, err = component.NewGitComponent(compCtx, appstudioComponent, r.Client)
+		}
 		if err != nil {
-			compLog.Error(err, "failed to handle component")
+			result.log.Error(err, "failed to handle component")
+			return finish(failed(target, mmv1alpha1.TargetReasonComponentError, err))
+		}
+		updateMode = getUpdateMode(check, appstudioComponent)
+
+		if value, exists := appstudioComponent.Annotations[MintMakerScheduleAnnotationName]; exists {
+			result.schedule, err = parseScanSchedule(value)
+			if err != nil {
+				return finish(failed(target, mmv1alpha1.TargetReasonComponentError, err))
+			}
+		}
+	} else {
+		result.log = log.WithValues("gitURL", target.URL, "credentialsNamespace", target.Namespace)
+		repoCtx := ctrllog.IntoContext(ctx, result.log)
+
+		var branches []string
+		for _, repository := range check.Spec.Repositories {
+			if repository.URL == target.URL {
+				branches = repository.Branches
+				break
+			}
+		}
+		comp, err := component.NewGitComponentFromURL(repoCtx, target.URL, branches, target.Namespace, r.Client)
+		if err != nil {
+			result.log.Error(err, "failed to handle repository")
+			return finish(failed(target, mmv1alpha1.TargetReasonComponentError,
+				fmt.Errorf("repository %s: %w", target.URL, err)))
+		}
+		result.comp = comp
+		updateMode = getUpdateMode(check, nil)
+	}
+
+	target.Host = result.comp.GetHost()
+	target.Repository = result.comp.GetRepository()
+	target.URL = result.comp.GetGitURL()
+	target.UpdateMode = updateMode
+	result.target = target
+
+	overrides, err := renovateOverrides(check, updateMode)
+	if err != nil {
+		return finish(failed(target, mmv1alpha1.TargetReasonInvalidOverrides, err))
+	}
+	result.comp.SetRenovateOverrides(overrides)
+
+	// Looking up the branches is the expensive part, e.g. GitHub API calls per version
+	result.branches, err = result.comp.GetBranches()
+	if err != nil {
+		result.log.Info("couldn't find versions which are branches for component", "component", result.comp.GetName(), "err", err)
+		reason := mmv1alpha1.TargetReasonNoBranches
+		if goerrors.Is(err, ghcomponent.ErrAppNotInstalled) {
+			reason = mmv1alpha1.TargetReasonAppNotInstalled
+		}
+		return finish(skipped(target, reason, err.Error()))
+	}
+	result.target.CredentialSource = mmv1alpha1.CredentialSource(result.comp.GetCredentialSource())
+	return result
+}
+
+// scheduleQueuedTargets creates PipelineRuns for the queued targets of the check,
+// as far as the limit of concurrent PipelineRuns allows
+func (r *DependencyUpdateCheckReconciler) scheduleQueuedTargets(ctx context.Context, check *mmv1alpha1.DependencyUpdateCheck) (ctrl.Result, error) {
+	log := ctrllog.FromContext(ctx)
+	status := &check.Status
+
+	capacity, err := r.pipelineRunCapacity(ctx)
+	if err != nil {
+		log.Error(err, "failed to count running PipelineRuns")
+		return ctrl.Result{}, err
+	}
+	if capacity == 0 {
+		log.Info("limit of concurrent PipelineRuns reached, targets stay queued", "queued", status.QueuedTargets)
+		return queuedResult(status), nil
+	}
+
+	run := &checkRun{
+		check:          check,
+		kiteSecretName: r.getKiteSecretName(ctx),
+		capacity:       capacity,
+	}
+	for i, target := range status.Targets {
+		if run.capacity == 0 {
+			break
+		}
+		if target.State != mmv1alpha1.TargetStateQueued {
 			continue
 		}
+		if target.NotBefore != nil && time.Now().Before(target.NotBefore.Time) {
+			continue
+		}
+		targetLog := log.WithValues("repository", target.Repository,
+			"branch", target.Branch,
+			"gitHost", target.Host)
+		targetCtx := ctrllog.IntoContext(ctx, targetLog)
 
-		branches, err := comp.GetBranches()
+		comp, hasSchedule, err := r.getQueuedGitComponent(targetCtx, target)
 		if err != nil {
-			compLog.Info("couldn't find versions which are branches for component", "component", appstudioComponent.Name, "err", err)
+			targetLog.Error(err, "failed to handle queued target")
+			status.Targets[i] = failed(target, mmv1alpha1.TargetReasonComponentError, err)
 			continue
 		}
+		overrides, err := renovateOverrides(check, target.UpdateMode)
+		if err != nil {
+			status.Targets[i] = failed(target, mmv1alpha1.TargetReasonInvalidOverrides, err)
+			continue
+		}
+		comp.SetRenovateOverrides(overrides)
 
-		host := comp.GetHost()
-		repository := comp.GetRepository()
-		for _, branchName := range branches {
-			// We need to create only one PipelineRun for a combination
-			// of repository+branch. We cannot use repository only,
-			// because the branch is used in Renovate's baseBranch config option.
-			branchLog := compLog.WithValues("repository", repository,
-				"branch", branchName,
-				"gitHost", host)
-			ctx = ctrllog.IntoContext(ctx, branchLog)
-
-			key := fmt.Sprintf("%s/%s@%s", host, repository, branchName)
-			if slices.Contains(processedComponents, key) {
-				// PipelineRun has already been created for this repo-branch
-				branchLog.Info("PipelineRun has been created for this component-key", "component-key", key)
+		status.Targets[i] = r.scheduleTarget(targetCtx, run, comp, target)
+		if hasSchedule && status.Targets[i].State == mmv1alpha1.TargetStateScheduled {
+			run.scanned = append(run.scanned, targetKey(target))
+		}
+	}
+
+	settleRun(status)
+	if err := r.updateStatus(ctx, check); err != nil {
+		return ctrl.Result{}, err
+	}
+	if err := r.recordScanTimes(ctx, run.scanned, time.Now()); err != nil {
+		log.Error(err, "failed to record scan times")
+		return ctrl.Result{}, err
+	}
+	return queuedResult(status), nil
+}
+
+// getQueuedGitComponent returns the git component of a queued target, without
+// discovering the targets of the check again, and whether its Component has a
+// scan schedule
+func (r *DependencyUpdateCheckReconciler) getQueuedGitComponent(ctx context.Context, target mmv1alpha1.TargetStatus) (component.GitComponent, bool, error) {
+	if target.Component == "" {
+		comp, err := component.NewGitComponentFromURL(ctx, target.URL, []string{target.Branch}, target.Namespace, r.Client)
+		return comp, false, err
+	}
+
+	appstudioComponent := &appstudiov1alpha1.Component{}
+	if err := r.Client.Get(ctx, types.NamespacedName{Namespace: target.Namespace, Name: target.Component}, appstudioComponent); err != nil {
+		return nil, false, err
+	}
+	_, hasSchedule := appstudioComponent.Annotations[MintMakerScheduleAnnotationName]
+	comp, err := component.NewGitComponent(ctx, appstudioComponent, r.Client)
+	return comp, hasSchedule, err
+}
+
+// queuedResult requeues checks which have queued targets when the next of
+// them is due. Targets waiting for running PipelineRuns are released by the
+// finished PipelineRuns, the requeue is just a safety net for them.
+func queuedResult(status *mmv1alpha1.DependencyUpdateCheckStatus) ctrl.Result {
+	var requeueAfter time.Duration
+	for _, target := range status.Targets {
+		if target.State != mmv1alpha1.TargetStateQueued {
+			continue
+		}
+		wait := queuedTargetsRecheckInterval
+		if target.NotBefore != nil {
+			if untilDue := time.Until(target.NotBefore.Time); untilDue > 0 {
+				wait = untilDue
+			}
+		}
+		if requeueAfter == 0 || wait < requeueAfter {
+			requeueAfter = wait
+		}
+	}
+	return ctrl.Result{RequeueAfter: requeueAfter}
+}
+
+// spreadTime returns the time when the PipelineRun of the target is due in the
+// window of spec.spreadOver, or nil if the check doesn't spread its PipelineRuns
+func spreadTime(check *mmv1alpha1.DependencyUpdateCheck, target mmv1alpha1.TargetStatus) *metav1.Time {
+	if check.Spec.SpreadOver == nil || check.Spec.SpreadOver.Duration <= 0 {
+		return nil
+	}
+	start := time.Now()
+	if check.Status.StartTime != nil {
+		start = check.Status.StartTime.Time
+	}
+	// All branches of a repository share the offset
+	due := metav1.NewTime(start.Add(spreadOffset(target.Host+"/"+target.Repository, check.Spec.SpreadOver.Duration)))
+	return &due
+}
+
+// spreadOffset returns the offset of the repository in the window, the same
+// repository always gets the same offset and the offsets are evenly distributed
+func spreadOffset(repository string, window time.Duration) time.Duration {
+	hash := fnv.New64a()
+	hash.Write([]byte(repository))
+	return time.Duration(hash.Sum64() % uint64(window))
+}
+
+// checkRun holds the state of processing a generation of a DependencyUpdateCheck
+type checkRun struct {
+	check *mmv1alpha1.DependencyUpdateCheck
+	// kiteSecretName is only set if Kite integration is enabled and the token secret is found
+	kiteSecretName string
+	// Track repository+branch keys for which we already created a PipelineRun
+	processedKeys sets.Set[string]
+	// Every target of the check is recorded in the status
+	targets []mmv1alpha1.TargetStatus
+	// Last scan times of repository+branch keys, for Components with a scan schedule
+	scanTimes map[string]time.Time
+	// Repository+branch keys of Components with a scan schedule scanned by this reconcile
+	scanned []string
+	// Number of PipelineRuns which can still be created, targets beyond it
+	// are queued. -1 means no limit.
+	capacity int
+}
+
+// renovateOverrides returns the Renovate config overrides of a target, the layer
+// of the update mode is applied first and the overrides of the check on top of it.
+// The overrides of the check must be a JSON object in every mode.
+func renovateOverrides(check *mmv1alpha1.DependencyUpdateCheck, updateMode mmv1alpha1.UpdateMode) ([]byte, error) {
+	var checkOverrides map[string]interface{}
+	if check.Spec.RenovateOverrides != nil {
+		if err := json.Unmarshal(check.Spec.RenovateOverrides.Raw, &checkOverrides); err != nil {
+			return nil, fmt.Errorf("error unmarshaling Renovate config overrides: %w", err)
+		}
+	}
+
+	if updateMode != mmv1alpha1.UpdateModeVulnerabilityOnly {
+		if check.Spec.RenovateOverrides == nil {
+			return nil, nil
+		}
+		return check.Spec.RenovateOverrides.Raw, nil
+	}
+
+	overrides := base.VulnerabilityOnlyConfig()
+	if checkOverrides != nil {
+		overrides = base.MergeRenovateConfig(overrides, checkOverrides)
+	}
+	return json.Marshal(overrides)
+}
+
+// processGitComponent schedules a PipelineRun for each branch of the git component
+// and records the outcome as targets of the run
+func (r *DependencyUpdateCheckReconciler) processGitComponent(ctx context.Context, run *checkRun, comp component.GitComponent, compTarget mmv1alpha1.TargetStatus, branches []string, schedule cron.Schedule) {
+	compLog := ctrllog.FromContext(ctx)
+
+	host := comp.GetHost()
+	repository := comp.GetRepository()
+
+	for _, branchName := range branches {
+		// We need to create only one PipelineRun for a combination
+		// of repository+branch. We cannot use repository only,
+		// because the branch is used in Renovate's baseBranch config option.
+		branchLog := compLog.WithValues("repository", repository,
+			"branch", branchName,
+			"gitHost", host)
+		ctx = ctrllog.IntoContext(ctx, branchLog)
+
+		target := compTarget
+		target.Branch = branchName
+
+		key := targetKey(target)
+		if run.processedKeys.Has(key) {
+			// PipelineRun has already been created for this repo-branch
+			branchLog.Info("PipelineRun has been created for this component-key", "component-key", key)
+			run.targets = append(run.targets, skipped(target, mmv1alpha1.TargetReasonDuplicate,
+				fmt.Sprintf("%s is already handled by another component of this check", key)))
+			continue
+		}
+		run.processedKeys.Insert(key)
+
+		if schedule != nil {
+			if next := nextScan(schedule, run.scanTimes, key, time.Now()); !next.IsZero() {
+				branchLog.Info("target was scanned recently, skipping it until its schedule is due", "next", next)
+				run.targets = append(run.targets, skipped(target, mmv1alpha1.TargetReasonNotDue,
+					fmt.Sprintf("last scanned at %s, the next scan is due at %s", run.scanTimes[key].Format(time.RFC3339), next.Format(time.RFC3339))))
 				continue
-			} else {
-				processedComponents = append(processedComponents, key)
 			}
+		}
 
-			plrName := fmt.Sprintf("renovate-%s-%s", timestamp, utils.RandomString(8))
-			pipelinerun, err := r.createPipelineRun(ctx, plrName, comp, branchName, kiteSecretName)
-			if err != nil {
-				branchLog.Error(err, "failed to create PipelineRun")
-				mintmakermetrics.CountScheduledRunFailure()
+		if run.check.Spec.DryRun {
+			if err := r.planTarget(ctx, comp, branchName); err != nil {
+				branchLog.Error(err, "failed to plan PipelineRun")
+				run.targets = append(run.targets, failed(target, mmv1alpha1.TargetReasonPlanningFailed, err))
 			} else {
-				branchLog.Info("created PipelineRun", "pipelineRun", pipelinerun.Name)
-				mintmakermetrics.CountScheduledRunSuccess()
+				branchLog.Info("planned PipelineRun")
+				target.State = mmv1alpha1.TargetStatePlanned
+				run.targets = append(run.targets, target)
 			}
+			continue
+		}
+
+		if notBefore := spreadTime(run.check, target); notBefore != nil && time.Now().Before(notBefore.Time) {
+			branchLog.Info("PipelineRuns are spread over a time window, queueing target", "notBefore", notBefore)
+			target.State = mmv1alpha1.TargetStateQueued
+			target.NotBefore = notBefore
+			run.targets = append(run.targets, target)
+			continue
 		}
+		if run.capacity == 0 {
+			branchLog.Info("limit of concurrent PipelineRuns reached, queueing target")
+			target.State = mmv1alpha1.TargetStateQueued
+			run.targets = append(run.targets, target)
+			continue
+		}
+
+		target = r.scheduleTarget(ctx, run, comp, target)
+		if schedule != nil && target.State == mmv1alpha1.TargetStateScheduled {
+			run.scanned = append(run.scanned, key)
+		}
+		run.targets = append(run.targets, target)
 	}
+}
 
-	return ctrl.Result{}, nil
+// scheduleTarget creates the PipelineRun of the target and returns the target
+// with the outcome
+func (r *DependencyUpdateCheckReconciler) scheduleTarget(ctx context.Context, run *checkRun, comp component.GitComponent, target mmv1alpha1.TargetStatus) mmv1alpha1.TargetStatus {
+	log := ctrllog.FromContext(ctx)
+
+	// A previous, interrupted reconcile may have created the PipelineRun already
+	plrName := pipelineRunName(run.check, target, target.Attempts+1)
+	existing := &tektonv1.PipelineRun{}
+	err := r.Client.Get(ctx, types.NamespacedName{Namespace: MintMakerNamespaceName, Name: plrName}, existing)
+	if err == nil {
+		log.Info("PipelineRun has been created for this target before", "pipelineRun", plrName)
+		return scheduled(target, plrName)
+	}
+	if !errors.IsNotFound(err) {
+		log.Error(err, "failed to look up PipelineRun")
+		return failed(target, mmv1alpha1.TargetReasonPipelineRunFailed, err)
+	}
+
+	// Renovate runs for the same branch would race on its branches and pull requests
+	running, err := r.getRunningPipelineRun(ctx, comp, target.Branch)
+	if err != nil {
+		log.Error(err, "failed to look up running PipelineRuns")
+		return failed(target, mmv1alpha1.TargetReasonPipelineRunFailed, err)
+	}
+	if running != "" {
+		log.Info("PipelineRun is already running for this target", "pipelineRun", running)
+		return skipped(target, mmv1alpha1.TargetReasonAlreadyRunning,
+			fmt.Sprintf("already running in PipelineRun %s", running))
+	}
+
+	pipelinerun, err := r.createPipelineRun(ctx, run, plrName, comp, target.Branch, target.UpdateMode)
+	if err != nil {
+		log.Error(err, "failed to create PipelineRun")
+		mintmakermetrics.CountScheduledRunFailure()
+		return failed(target, mmv1alpha1.TargetReasonPipelineRunFailed, err)
+	}
+
+	log.Info("created PipelineRun", "pipelineRun", pipelinerun.Name)
+	r.createdPipelineRuns.add(pipelinerun.Name)
+	mintmakermetrics.CountScheduledRunSuccess()
+	if run.capacity > 0 {
+		run.capacity--
+	}
+	return scheduled(target, pipelinerun.Name)
+}
+
+// pipelineRunName returns the name of the PipelineRun for an attempt of the
+// target. The name is derived from the generation of the check and the
+// target, so creating the PipelineRun again finds the existing one.
+func pipelineRunName(check *mmv1alpha1.DependencyUpdateCheck, target mmv1alpha1.TargetStatus, attempt int32) string {
+	hash := sha256.Sum256([]byte(fmt.Sprintf("%s/%d/%s/%d", check.UID, check.Generation, targetKey(target), attempt)))
+	return "renovate-" + hex.EncodeToString(hash[:])[:20]
+}
+
+// updateStatus stores the status of the DependencyUpdateCheck. The status is
+// written on the version of the check it was computed from, so a conflicting
+// write, e.g. a retried target, isn't overwritten. The check is reconciled
+// again on a conflict, the PipelineRuns created meanwhile are found by name.
+func (r *DependencyUpdateCheckReconciler) updateStatus(ctx context.Context, dependencyupdatecheck *mmv1alpha1.DependencyUpdateCheck) error {
+	log := ctrllog.FromContext(ctx)
+
+	if err := r.Client.Status().Update(ctx, dependencyupdatecheck); err != nil {
+		if errors.IsConflict(err) {
+			log.Info("DependencyUpdateCheck has been modified, reconciling it again")
+		} else {
+			log.Error(err, "failed to update DependencyUpdateCheck status")
+		}
+		return err
+	}
+	mintmakermetrics.RecordDependencyUpdateCheckQueuedTargets(dependencyupdatecheck.Namespace,
+		dependencyupdatecheck.Name, dependencyupdatecheck.Status.QueuedTargets)
+	return nil
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *DependencyUpdateCheckReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	// We only react to Create events for DependencyUpdateCheck in mintmaker namespace.
-	// Namespace filtering is handled by the manager's cache configuration.
-	return ctrl.NewControllerManagedBy(mgr).
+	// We only react to Create events, spec changes and deletions for DependencyUpdateCheck in mintmaker
+	// namespace. Namespace filtering is handled by the manager's cache configuration.
+	// Checks with queued targets are also triggered by finished PipelineRuns.
+	builder := ctrl.NewControllerManagedBy(mgr).
 		For(&mmv1alpha1.DependencyUpdateCheck{}).
 		WithEventFilter(predicate.Funcs{
-			CreateFunc:  func(e event.CreateEvent) bool { return true },
-			DeleteFunc:  func(e event.DeleteEvent) bool { return false },
-			UpdateFunc:  func(e event.UpdateEvent) bool { return false },
+			CreateFunc: func(e event.CreateEvent) bool { return true },
+			DeleteFunc: func(e event.DeleteEvent) bool { return false },
+			UpdateFunc: func(e event.UpdateEvent) bool {
+				// Deleting a check with the cleanup finalizer only sets its deletion timestamp
+				if e.ObjectNew.GetDeletionTimestamp() != nil {
+					return true
+				}
+				return e.ObjectNew.GetGeneration() != e.ObjectOld.GetGeneration()
+			},
 			GenericFunc: func(e event.GenericEvent) bool { return false },
-		}).
-		Complete(r)
+		})
+	if r.QueueReleases != nil {
+		builder = builder.WatchesRawSource(source.Channel(r.QueueReleases, &handler.EnqueueRequestForObject{}))
+	}
+	return builder.Complete(r)
 }
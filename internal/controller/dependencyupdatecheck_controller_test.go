@@ -16,15 +16,32 @@ package controller
 
 import (
 	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+
+	appstudiov1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
+
+	mmv1alpha1 "github.com/konflux-ci/mintmaker/api/v1alpha1"
+	"github.com/konflux-ci/mintmaker/internal/component/base"
 	ghcomponent "github.com/konflux-ci/mintmaker/internal/component/github"
+	"github.com/konflux-ci/mintmaker/internal/component/gitlab"
 	. "github.com/konflux-ci/mintmaker/internal/constant"
+	"github.com/konflux-ci/mintmaker/internal/utils"
 )
 
 var _ = Describe("DependencyUpdateCheck Controller", func() {
@@ -114,6 +131,388 @@ var _ = Describe("DependencyUpdateCheck Controller", func() {
 				deleteDependencyUpdateCheck(dependencyUpdateCheckKey)
 			})
 
+			It("should record every scheduled target in the status", func() {
+				createDependencyUpdateCheck(dependencyUpdateCheckKey, false, nil)
+				Eventually(func(g Gomega) {
+					dependencyUpdateCheck := getDependencyUpdateCheck(dependencyUpdateCheckKey)
+					g.Expect(dependencyUpdateCheck.Status.Targets).To(HaveLen(expectedPipelineRuns))
+					g.Expect(dependencyUpdateCheck.Status.TotalTargets).To(BeEquivalentTo(expectedPipelineRuns))
+					g.Expect(dependencyUpdateCheck.Status.ScheduledTargets).To(BeEquivalentTo(expectedPipelineRuns))
+					for _, target := range dependencyUpdateCheck.Status.Targets {
+						g.Expect(target.State).To(Equal(mmv1alpha1.TargetStateScheduled))
+						g.Expect(target.Namespace).To(Equal(componentNamespace))
+						g.Expect(target.Component).To(Equal(componentName))
+						g.Expect(target.Host).To(Equal("github.com"))
+						g.Expect(target.Repository).To(Equal("testcomp"))
+						g.Expect(target.PipelineRun).NotTo(BeEmpty())
+					}
+					g.Expect(meta.IsStatusConditionTrue(dependencyUpdateCheck.Status.Conditions, mmv1alpha1.ConditionCompleted)).To(BeTrue())
+					g.Expect(meta.IsStatusConditionFalse(dependencyUpdateCheck.Status.Conditions, mmv1alpha1.ConditionDegraded)).To(BeTrue())
+				}, timeout, interval).Should(Succeed())
+				deleteDependencyUpdateCheck(dependencyUpdateCheckKey)
+			})
+
+			It("should record the processed generation and phase in the status", func() {
+				createDependencyUpdateCheck(dependencyUpdateCheckKey, false, nil)
+				Eventually(func(g Gomega) {
+					dependencyUpdateCheck := getDependencyUpdateCheck(dependencyUpdateCheckKey)
+					g.Expect(dependencyUpdateCheck.Status.ObservedGeneration).To(Equal(dependencyUpdateCheck.Generation))
+					g.Expect(dependencyUpdateCheck.Status.Phase).To(Equal(mmv1alpha1.PhaseCompleted))
+					g.Expect(dependencyUpdateCheck.Status.StartTime).NotTo(BeNil())
+					g.Expect(dependencyUpdateCheck.Status.CompletionTime).NotTo(BeNil())
+				}, timeout, interval).Should(Succeed())
+				deleteDependencyUpdateCheck(dependencyUpdateCheckKey)
+			})
+
+			It("should run the check again when the rerun token changes", func() {
+				createDependencyUpdateCheck(dependencyUpdateCheckKey, false, nil)
+				Eventually(listPipelineRuns).WithArguments(MintMakerNamespaceName).Should(HaveLen(expectedPipelineRuns))
+				Eventually(func() mmv1alpha1.DependencyUpdateCheckPhase {
+					return getDependencyUpdateCheck(dependencyUpdateCheckKey).Status.Phase
+				}, timeout, interval).Should(Equal(mmv1alpha1.PhaseCompleted))
+				finishPipelineRuns(MintMakerNamespaceName)
+
+				dependencyUpdateCheck := getDependencyUpdateCheck(dependencyUpdateCheckKey)
+				dependencyUpdateCheck.Spec.RerunToken = "1"
+				Expect(k8sClient.Update(ctx, dependencyUpdateCheck)).Should(Succeed())
+
+				Eventually(listPipelineRuns).WithArguments(MintMakerNamespaceName).Should(HaveLen(2 * expectedPipelineRuns))
+				Eventually(func(g Gomega) {
+					dependencyUpdateCheck := getDependencyUpdateCheck(dependencyUpdateCheckKey)
+					g.Expect(dependencyUpdateCheck.Status.ObservedGeneration).To(Equal(dependencyUpdateCheck.Generation))
+					g.Expect(dependencyUpdateCheck.Status.Phase).To(Equal(mmv1alpha1.PhaseCompleted))
+					g.Expect(dependencyUpdateCheck.Status.Targets).To(HaveLen(expectedPipelineRuns))
+				}, timeout, interval).Should(Succeed())
+				deleteDependencyUpdateCheck(dependencyUpdateCheckKey)
+			})
+
+			It("should skip the targets of components with a schedule which were scanned recently", func() {
+				scanTimesConfigMap := types.NamespacedName{Namespace: MintMakerNamespaceName, Name: scanTimesConfigMapName}
+				deleteConfigMap(scanTimesConfigMap)
+				defer deleteConfigMap(scanTimesConfigMap)
+				annotateComponent(types.NamespacedName{Name: componentName, Namespace: componentNamespace},
+					map[string]string{MintMakerScheduleAnnotationName: "weekly"})
+
+				createDependencyUpdateCheck(dependencyUpdateCheckKey, false, nil)
+				Eventually(listPipelineRuns).WithArguments(MintMakerNamespaceName).Should(HaveLen(expectedPipelineRuns))
+				Eventually(func() mmv1alpha1.DependencyUpdateCheckPhase {
+					return getDependencyUpdateCheck(dependencyUpdateCheckKey).Status.Phase
+				}, timeout, interval).Should(Equal(mmv1alpha1.PhaseCompleted))
+				Expect(getConfigMap(scanTimesConfigMap).Data).To(HaveKey(scanTimesKey))
+				finishPipelineRuns(MintMakerNamespaceName)
+
+				dependencyUpdateCheck := getDependencyUpdateCheck(dependencyUpdateCheckKey)
+				dependencyUpdateCheck.Spec.RerunToken = "1"
+				Expect(k8sClient.Update(ctx, dependencyUpdateCheck)).Should(Succeed())
+
+				Eventually(func(g Gomega) {
+					dependencyUpdateCheck := getDependencyUpdateCheck(dependencyUpdateCheckKey)
+					g.Expect(dependencyUpdateCheck.Status.ObservedGeneration).To(Equal(dependencyUpdateCheck.Generation))
+					g.Expect(dependencyUpdateCheck.Status.Phase).To(Equal(mmv1alpha1.PhaseCompleted))
+					g.Expect(dependencyUpdateCheck.Status.SkippedTargets).To(BeEquivalentTo(expectedPipelineRuns))
+					for _, target := range dependencyUpdateCheck.Status.Targets {
+						g.Expect(target.Reason).To(Equal(mmv1alpha1.TargetReasonNotDue))
+					}
+				}, timeout, interval).Should(Succeed())
+				Expect(listPipelineRuns(MintMakerNamespaceName)).To(HaveLen(expectedPipelineRuns))
+				deleteDependencyUpdateCheck(dependencyUpdateCheckKey)
+			})
+
+			It("should skip the targets which already have a running pipelinerun", func() {
+				createDependencyUpdateCheck(dependencyUpdateCheckKey, false, nil)
+				Eventually(listPipelineRuns).WithArguments(MintMakerNamespaceName).Should(HaveLen(expectedPipelineRuns))
+
+				otherCheckKey := types.NamespacedName{Namespace: MintMakerNamespaceName, Name: "other-" + dependencyUpdateCheckName}
+				createDependencyUpdateCheck(otherCheckKey, false, nil)
+				Eventually(func(g Gomega) {
+					otherCheck := getDependencyUpdateCheck(otherCheckKey)
+					g.Expect(otherCheck.Status.Phase).To(Equal(mmv1alpha1.PhaseCompleted))
+					g.Expect(otherCheck.Status.SkippedTargets).To(BeEquivalentTo(expectedPipelineRuns))
+					for _, target := range otherCheck.Status.Targets {
+						g.Expect(target.Reason).To(Equal(mmv1alpha1.TargetReasonAlreadyRunning))
+					}
+				}, timeout, interval).Should(Succeed())
+				Expect(listPipelineRuns(MintMakerNamespaceName)).To(HaveLen(expectedPipelineRuns))
+				deleteDependencyUpdateCheck(otherCheckKey)
+				deleteDependencyUpdateCheck(dependencyUpdateCheckKey)
+			})
+
+			It("should not run the check again when only its metadata changes", func() {
+				createDependencyUpdateCheck(dependencyUpdateCheckKey, false, nil)
+				Eventually(listPipelineRuns).WithArguments(MintMakerNamespaceName).Should(HaveLen(expectedPipelineRuns))
+
+				dependencyUpdateCheck := getDependencyUpdateCheck(dependencyUpdateCheckKey)
+				dependencyUpdateCheck.Labels = map[string]string{"test": "label"}
+				Expect(k8sClient.Update(ctx, dependencyUpdateCheck)).Should(Succeed())
+
+				Consistently(listPipelineRuns).WithArguments(MintMakerNamespaceName).Should(HaveLen(expectedPipelineRuns))
+				deleteDependencyUpdateCheck(dependencyUpdateCheckKey)
+			})
+
+			It("should only plan the pipelineruns in dry run mode", func() {
+				createDependencyUpdateCheckWithSpec(dependencyUpdateCheckKey, mmv1alpha1.DependencyUpdateCheckSpec{DryRun: true})
+				Eventually(func(g Gomega) {
+					dependencyUpdateCheck := getDependencyUpdateCheck(dependencyUpdateCheckKey)
+					g.Expect(dependencyUpdateCheck.Status.Phase).To(Equal(mmv1alpha1.PhaseCompleted))
+					g.Expect(dependencyUpdateCheck.Status.PlannedTargets).To(BeEquivalentTo(expectedPipelineRuns))
+					for _, target := range dependencyUpdateCheck.Status.Targets {
+						g.Expect(target.State).To(Equal(mmv1alpha1.TargetStatePlanned))
+						g.Expect(target.PipelineRun).To(BeEmpty())
+					}
+				}, timeout, interval).Should(Succeed())
+				Consistently(listPipelineRuns).WithArguments(MintMakerNamespaceName).Should(HaveLen(0))
+				deleteDependencyUpdateCheck(dependencyUpdateCheckKey)
+			})
+
+			It("should report failed lookups in dry run mode", func() {
+				ghcomponent.GetRenovateConfigFn = func(registrySecret *corev1.Secret, currentBranch string) (string, error) {
+					return "", fmt.Errorf("invalid renovate config")
+				}
+				createDependencyUpdateCheckWithSpec(dependencyUpdateCheckKey, mmv1alpha1.DependencyUpdateCheckSpec{DryRun: true})
+				Eventually(func(g Gomega) {
+					dependencyUpdateCheck := getDependencyUpdateCheck(dependencyUpdateCheckKey)
+					g.Expect(dependencyUpdateCheck.Status.Phase).To(Equal(mmv1alpha1.PhaseFailed))
+					g.Expect(dependencyUpdateCheck.Status.FailedTargets).To(BeEquivalentTo(expectedPipelineRuns))
+					g.Expect(dependencyUpdateCheck.Status.Targets[0].Reason).To(Equal(mmv1alpha1.TargetReasonPlanningFailed))
+					g.Expect(dependencyUpdateCheck.Status.Targets[0].Message).To(ContainSubstring("invalid renovate config"))
+				}, timeout, interval).Should(Succeed())
+				Expect(listPipelineRuns(MintMakerNamespaceName)).Should(HaveLen(0))
+				deleteDependencyUpdateCheck(dependencyUpdateCheckKey)
+			})
+
+			It("should only process components matching the component selector", func() {
+				labelComponent(types.NamespacedName{Name: componentName, Namespace: componentNamespace}, map[string]string{"tier": "prod"})
+				createDependencyUpdateCheckWithSpec(dependencyUpdateCheckKey, mmv1alpha1.DependencyUpdateCheckSpec{
+					ComponentSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "prod"}},
+				})
+				Eventually(listPipelineRuns).WithArguments(MintMakerNamespaceName).Should(HaveLen(expectedPipelineRuns))
+				deleteDependencyUpdateCheck(dependencyUpdateCheckKey)
+			})
+
+			It("should not process components which don't match the component selector", func() {
+				createDependencyUpdateCheckWithSpec(dependencyUpdateCheckKey, mmv1alpha1.DependencyUpdateCheckSpec{
+					ComponentSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "prod"}},
+				})
+				Eventually(func() mmv1alpha1.DependencyUpdateCheckPhase {
+					return getDependencyUpdateCheck(dependencyUpdateCheckKey).Status.Phase
+				}, timeout, interval).Should(Equal(mmv1alpha1.PhaseCompleted))
+				Expect(getDependencyUpdateCheck(dependencyUpdateCheckKey).Status.Targets).To(BeEmpty())
+				Expect(listPipelineRuns(MintMakerNamespaceName)).Should(HaveLen(0))
+				deleteDependencyUpdateCheck(dependencyUpdateCheckKey)
+			})
+
+			It("should process components from namespaces matching the namespace selector", func() {
+				labelNamespace(componentNamespace, map[string]string{"mintmaker-test": "selected"})
+				createDependencyUpdateCheckWithSpec(dependencyUpdateCheckKey, mmv1alpha1.DependencyUpdateCheckSpec{
+					NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"mintmaker-test": "selected"}},
+				})
+				Eventually(listPipelineRuns).WithArguments(MintMakerNamespaceName).Should(HaveLen(expectedPipelineRuns))
+				deleteDependencyUpdateCheck(dependencyUpdateCheckKey)
+			})
+
+			It("should not process components twice when they are both listed and selected", func() {
+				labelNamespace(componentNamespace, map[string]string{"mintmaker-test": "selected"})
+				createDependencyUpdateCheckWithSpec(dependencyUpdateCheckKey, mmv1alpha1.DependencyUpdateCheckSpec{
+					Namespaces:        []mmv1alpha1.NamespaceSpec{{Namespace: componentNamespace}},
+					NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"mintmaker-test": "selected"}},
+				})
+				Eventually(func(g Gomega) {
+					dependencyUpdateCheck := getDependencyUpdateCheck(dependencyUpdateCheckKey)
+					g.Expect(dependencyUpdateCheck.Status.Phase).To(Equal(mmv1alpha1.PhaseCompleted))
+					g.Expect(dependencyUpdateCheck.Status.Targets).To(HaveLen(expectedPipelineRuns))
+				}, timeout, interval).Should(Succeed())
+				Expect(listPipelineRuns(MintMakerNamespaceName)).Should(HaveLen(expectedPipelineRuns))
+				deleteDependencyUpdateCheck(dependencyUpdateCheckKey)
+			})
+
+			It("should only process components of applications matching the application selector", func() {
+				// Only the v1 component model has an application
+				if crdVersion != "v1" {
+					return
+				}
+				applicationKey := types.NamespacedName{Name: "app", Namespace: componentNamespace}
+				createApplication(applicationKey, map[string]string{"team": "security"})
+				createDependencyUpdateCheckWithSpec(dependencyUpdateCheckKey, mmv1alpha1.DependencyUpdateCheckSpec{
+					ApplicationSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "security"}},
+				})
+				Eventually(listPipelineRuns).WithArguments(MintMakerNamespaceName).Should(HaveLen(expectedPipelineRuns))
+				deleteDependencyUpdateCheck(dependencyUpdateCheckKey)
+				deleteApplication(applicationKey)
+			})
+
+			It("should report components excluded by name as skipped", func() {
+				createDependencyUpdateCheckWithSpec(dependencyUpdateCheckKey, mmv1alpha1.DependencyUpdateCheckSpec{
+					Exclude: &mmv1alpha1.ExcludeSpec{
+						Components: []mmv1alpha1.ExcludedComponent{{Namespace: componentNamespace, Component: componentName}},
+					},
+				})
+				Eventually(func(g Gomega) {
+					dependencyUpdateCheck := getDependencyUpdateCheck(dependencyUpdateCheckKey)
+					g.Expect(dependencyUpdateCheck.Status.Targets).To(HaveLen(1))
+					g.Expect(dependencyUpdateCheck.Status.Targets[0].State).To(Equal(mmv1alpha1.TargetStateSkipped))
+					g.Expect(dependencyUpdateCheck.Status.Targets[0].Reason).To(Equal(mmv1alpha1.TargetReasonExcluded))
+					g.Expect(dependencyUpdateCheck.Status.Targets[0].Message).To(ContainSubstring("excluded by rule"))
+				}, timeout, interval).Should(Succeed())
+				Expect(listPipelineRuns(MintMakerNamespaceName)).Should(HaveLen(0))
+				deleteDependencyUpdateCheck(dependencyUpdateCheckKey)
+			})
+
+			It("should report components with a repository matching an exclude pattern as skipped", func() {
+				createDependencyUpdateCheckWithSpec(dependencyUpdateCheckKey, mmv1alpha1.DependencyUpdateCheckSpec{
+					Exclude: &mmv1alpha1.ExcludeSpec{Repositories: []string{"github.com/test*"}},
+				})
+				Eventually(func(g Gomega) {
+					dependencyUpdateCheck := getDependencyUpdateCheck(dependencyUpdateCheckKey)
+					g.Expect(dependencyUpdateCheck.Status.Targets).To(HaveLen(1))
+					g.Expect(dependencyUpdateCheck.Status.Targets[0].Reason).To(Equal(mmv1alpha1.TargetReasonExcluded))
+				}, timeout, interval).Should(Succeed())
+				Expect(listPipelineRuns(MintMakerNamespaceName)).Should(HaveLen(0))
+				deleteDependencyUpdateCheck(dependencyUpdateCheckKey)
+			})
+
+			It("should process components with a repository not matching any exclude pattern", func() {
+				createDependencyUpdateCheckWithSpec(dependencyUpdateCheckKey, mmv1alpha1.DependencyUpdateCheckSpec{
+					Exclude: &mmv1alpha1.ExcludeSpec{Repositories: []string{"github.com/legacy-*", "gitlab.com/*"}},
+				})
+				Eventually(listPipelineRuns).WithArguments(MintMakerNamespaceName).Should(HaveLen(expectedPipelineRuns))
+				deleteDependencyUpdateCheck(dependencyUpdateCheckKey)
+			})
+
+			It("should report components with mintmaker disabled as skipped", func() {
+				disableComponentMintmaker(types.NamespacedName{Name: componentName, Namespace: componentNamespace})
+				createDependencyUpdateCheck(dependencyUpdateCheckKey, false, nil)
+				Eventually(func(g Gomega) {
+					dependencyUpdateCheck := getDependencyUpdateCheck(dependencyUpdateCheckKey)
+					g.Expect(dependencyUpdateCheck.Status.Targets).To(HaveLen(1))
+					g.Expect(dependencyUpdateCheck.Status.SkippedTargets).To(BeEquivalentTo(1))
+					g.Expect(dependencyUpdateCheck.Status.Targets[0].State).To(Equal(mmv1alpha1.TargetStateSkipped))
+					g.Expect(dependencyUpdateCheck.Status.Targets[0].Reason).To(Equal(mmv1alpha1.TargetReasonDisabled))
+				}, timeout, interval).Should(Succeed())
+				Expect(listPipelineRuns(MintMakerNamespaceName)).Should(HaveLen(0))
+				deleteDependencyUpdateCheck(dependencyUpdateCheckKey)
+			})
+
+			It("should report components of a namespace with mintmaker disabled as skipped", func() {
+				annotateNamespace(componentNamespace, map[string]string{MintMakerDisabledAnnotationName: "true"})
+				defer annotateNamespace(componentNamespace, map[string]string{MintMakerDisabledAnnotationName: ""})
+				createDependencyUpdateCheck(dependencyUpdateCheckKey, false, nil)
+				Eventually(func(g Gomega) {
+					dependencyUpdateCheck := getDependencyUpdateCheck(dependencyUpdateCheckKey)
+					g.Expect(dependencyUpdateCheck.Status.Targets).To(HaveLen(1))
+					g.Expect(dependencyUpdateCheck.Status.Targets[0].State).To(Equal(mmv1alpha1.TargetStateSkipped))
+					g.Expect(dependencyUpdateCheck.Status.Targets[0].Reason).To(Equal(mmv1alpha1.TargetReasonNamespaceDisabled))
+					g.Expect(dependencyUpdateCheck.Status.Targets[0].Message).To(ContainSubstring("Namespace " + componentNamespace))
+				}, timeout, interval).Should(Succeed())
+				Expect(listPipelineRuns(MintMakerNamespaceName)).Should(HaveLen(0))
+				deleteDependencyUpdateCheck(dependencyUpdateCheckKey)
+			})
+
+			It("should process components with mintmaker enabled in a namespace with mintmaker disabled", func() {
+				annotateNamespace(componentNamespace, map[string]string{MintMakerDisabledAnnotationName: "true"})
+				defer annotateNamespace(componentNamespace, map[string]string{MintMakerDisabledAnnotationName: ""})
+				annotateComponent(types.NamespacedName{Name: componentName, Namespace: componentNamespace},
+					map[string]string{MintMakerEnabledAnnotationName: "true"})
+				createDependencyUpdateCheck(dependencyUpdateCheckKey, false, nil)
+				Eventually(listPipelineRuns).WithArguments(MintMakerNamespaceName).Should(HaveLen(expectedPipelineRuns))
+				deleteDependencyUpdateCheck(dependencyUpdateCheckKey)
+			})
+
+			It("should report duplicate repository branches as skipped", func() {
+				if crdVersion != "v2" {
+					return
+				}
+				ghcomponent.GetBranchesFn = func() ([]string, error) {
+					return []string{"gitrevision", "gitrevision"}, nil
+				}
+				createDependencyUpdateCheck(dependencyUpdateCheckKey, false, nil)
+				Eventually(func(g Gomega) {
+					dependencyUpdateCheck := getDependencyUpdateCheck(dependencyUpdateCheckKey)
+					g.Expect(dependencyUpdateCheck.Status.Targets).To(HaveLen(2))
+					g.Expect(dependencyUpdateCheck.Status.ScheduledTargets).To(BeEquivalentTo(1))
+					g.Expect(dependencyUpdateCheck.Status.SkippedTargets).To(BeEquivalentTo(1))
+					g.Expect(dependencyUpdateCheck.Status.Targets[1].Reason).To(Equal(mmv1alpha1.TargetReasonDuplicate))
+				}, timeout, interval).Should(Succeed())
+				Expect(listPipelineRuns(MintMakerNamespaceName)).Should(HaveLen(1))
+				deleteDependencyUpdateCheck(dependencyUpdateCheckKey)
+			})
+
+			It("should only process the listed repositories when no components are selected", func() {
+				createDependencyUpdateCheckWithSpec(dependencyUpdateCheckKey, mmv1alpha1.DependencyUpdateCheckSpec{
+					Repositories: []mmv1alpha1.RepositorySpec{{URL: "https://github.com/other.git", Branches: []string{"main"}}},
+				})
+				Eventually(func(g Gomega) {
+					dependencyUpdateCheck := getDependencyUpdateCheck(dependencyUpdateCheckKey)
+					g.Expect(dependencyUpdateCheck.Status.Targets).To(HaveLen(expectedPipelineRuns))
+					for _, target := range dependencyUpdateCheck.Status.Targets {
+						g.Expect(target.State).To(Equal(mmv1alpha1.TargetStateScheduled))
+						g.Expect(target.Namespace).To(Equal(MintMakerNamespaceName))
+						g.Expect(target.Component).To(BeEmpty())
+						g.Expect(target.Repository).To(Equal("other"))
+					}
+				}, timeout, interval).Should(Succeed())
+				for _, plr := range listPipelineRuns(MintMakerNamespaceName) {
+					Expect(plr.Annotations).To(HaveKeyWithValue(MintMakerGitURLAnnotationName, "https://github.com/other.git"))
+				}
+				deleteDependencyUpdateCheck(dependencyUpdateCheckKey)
+			})
+
+			It("should label the pipelineruns of a vulnerability-only check", func() {
+				createDependencyUpdateCheckWithSpec(dependencyUpdateCheckKey, mmv1alpha1.DependencyUpdateCheckSpec{
+					UpdateMode: mmv1alpha1.UpdateModeVulnerabilityOnly,
+				})
+				Eventually(listPipelineRuns).WithArguments(MintMakerNamespaceName).Should(HaveLen(expectedPipelineRuns))
+				for _, plr := range listPipelineRuns(MintMakerNamespaceName) {
+					Expect(plr.Labels).To(HaveKeyWithValue(MintMakerUpdateModeLabelName, string(mmv1alpha1.UpdateModeVulnerabilityOnly)))
+				}
+				deleteDependencyUpdateCheck(dependencyUpdateCheckKey)
+			})
+
+			It("should use the vulnerability-only mode for components with the update-mode annotation", func() {
+				annotateComponent(types.NamespacedName{Name: componentName, Namespace: componentNamespace},
+					map[string]string{MintMakerUpdateModeAnnotationName: string(mmv1alpha1.UpdateModeVulnerabilityOnly)})
+				createDependencyUpdateCheck(dependencyUpdateCheckKey, false, nil)
+				Eventually(func(g Gomega) {
+					dependencyUpdateCheck := getDependencyUpdateCheck(dependencyUpdateCheckKey)
+					g.Expect(dependencyUpdateCheck.Status.Targets).To(HaveLen(expectedPipelineRuns))
+					for _, target := range dependencyUpdateCheck.Status.Targets {
+						g.Expect(target.UpdateMode).To(Equal(mmv1alpha1.UpdateModeVulnerabilityOnly))
+					}
+				}, timeout, interval).Should(Succeed())
+				for _, plr := range listPipelineRuns(MintMakerNamespaceName) {
+					Expect(plr.Labels).To(HaveKeyWithValue(MintMakerUpdateModeLabelName, string(mmv1alpha1.UpdateModeVulnerabilityOnly)))
+				}
+				deleteDependencyUpdateCheck(dependencyUpdateCheckKey)
+			})
+
+			It("should label the pipelineruns with the check which created them", func() {
+				createDependencyUpdateCheck(dependencyUpdateCheckKey, false, nil)
+				Eventually(listPipelineRuns).WithArguments(MintMakerNamespaceName).Should(HaveLen(expectedPipelineRuns))
+				dependencyUpdateCheck := getDependencyUpdateCheck(dependencyUpdateCheckKey)
+				Expect(dependencyUpdateCheck.Finalizers).To(BeEmpty())
+				for _, plr := range listPipelineRuns(MintMakerNamespaceName) {
+					Expect(plr.Labels).To(HaveKeyWithValue(MintMakerCheckLabelName, dependencyUpdateCheckName))
+					Expect(plr.Labels).To(HaveKeyWithValue(MintMakerCheckUIDLabelName, string(dependencyUpdateCheck.UID)))
+					Expect(plr.OwnerReferences).To(BeEmpty())
+				}
+				deleteDependencyUpdateCheck(dependencyUpdateCheckKey)
+			})
+
+			It("should cancel the pipelineruns when a check with cleanup is deleted", func() {
+				createDependencyUpdateCheckWithSpec(dependencyUpdateCheckKey, mmv1alpha1.DependencyUpdateCheckSpec{CleanupOnDelete: true})
+				Eventually(listPipelineRuns).WithArguments(MintMakerNamespaceName).Should(HaveLen(expectedPipelineRuns))
+				dependencyUpdateCheck := getDependencyUpdateCheck(dependencyUpdateCheckKey)
+				Expect(dependencyUpdateCheck.Finalizers).To(ContainElement(MintMakerCleanupFinalizerName))
+				for _, plr := range listPipelineRuns(MintMakerNamespaceName) {
+					Expect(metav1.IsControlledBy(&plr, dependencyUpdateCheck)).To(BeFalse())
+					Expect(plr.OwnerReferences).To(ContainElement(HaveField("UID", dependencyUpdateCheck.UID)))
+				}
+
+				deleteDependencyUpdateCheck(dependencyUpdateCheckKey)
+				for _, plr := range listPipelineRuns(MintMakerNamespaceName) {
+					Expect(plr.Spec.Status).To(BeEquivalentTo(tektonv1.PipelineRunSpecStatusCancelled))
+				}
+			})
+
 			It("should create pipelineruns only for versions that are branches (filter out tags)", func() {
 				if crdVersion != "v2" {
 					return
@@ -126,10 +525,144 @@ var _ = Describe("DependencyUpdateCheck Controller", func() {
 				deleteDependencyUpdateCheck(dependencyUpdateCheckKey)
 			})
 
+			It("should queue targets beyond the limit of concurrent pipelineruns", func() {
+				if crdVersion != "v2" {
+					return
+				}
+				// Checks outside the mintmaker namespace are ignored by the controller
+				// of the suite, so only the limited reconciler processes this one
+				limitedCheckKey := types.NamespacedName{Namespace: "default", Name: dependencyUpdateCheckName}
+				reconciler := &DependencyUpdateCheckReconciler{Client: k8sClient, Scheme: k8sClient.Scheme(), MaxConcurrentPipelineRuns: 2}
+				createDependencyUpdateCheck(limitedCheckKey, false, nil)
+
+				result, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: limitedCheckKey})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+				Expect(listPipelineRuns(MintMakerNamespaceName)).To(HaveLen(2))
+				dependencyUpdateCheck := getDependencyUpdateCheck(limitedCheckKey)
+				Expect(dependencyUpdateCheck.Status.Phase).To(Equal(mmv1alpha1.PhaseRunning))
+				Expect(dependencyUpdateCheck.Status.ScheduledTargets).To(BeEquivalentTo(2))
+				Expect(dependencyUpdateCheck.Status.QueuedTargets).To(BeEquivalentTo(1))
+				Expect(meta.FindStatusCondition(dependencyUpdateCheck.Status.Conditions, mmv1alpha1.ConditionCompleted).Reason).To(Equal("Queued"))
+
+				// The queue isn't released while the pipelineruns are running
+				_, err = reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: limitedCheckKey})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(listPipelineRuns(MintMakerNamespaceName)).To(HaveLen(2))
+
+				plr := listPipelineRuns(MintMakerNamespaceName)[0]
+				plr.Status.MarkSucceeded(string(tektonv1.PipelineRunReasonSuccessful), "%s")
+				Expect(k8sClient.Status().Update(ctx, &plr)).Should(Succeed())
+
+				result, err = reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: limitedCheckKey})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.RequeueAfter).To(BeZero())
+				Expect(listPipelineRuns(MintMakerNamespaceName)).To(HaveLen(3))
+				dependencyUpdateCheck = getDependencyUpdateCheck(limitedCheckKey)
+				Expect(dependencyUpdateCheck.Status.Phase).To(Equal(mmv1alpha1.PhaseCompleted))
+				Expect(dependencyUpdateCheck.Status.ScheduledTargets).To(BeEquivalentTo(3))
+				Expect(dependencyUpdateCheck.Status.QueuedTargets).To(BeZero())
+				deleteDependencyUpdateCheck(limitedCheckKey)
+			})
+
+			It("should not create the pipelineruns again when a run is repeated after a crash", func() {
+				// Checks outside the mintmaker namespace are ignored by the controller
+				// of the suite, so only this reconciler processes this one
+				crashedCheckKey := types.NamespacedName{Namespace: "default", Name: dependencyUpdateCheckName}
+				reconciler := &DependencyUpdateCheckReconciler{Client: k8sClient, Scheme: k8sClient.Scheme()}
+				createDependencyUpdateCheck(crashedCheckKey, false, nil)
+
+				_, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: crashedCheckKey})
+				Expect(err).NotTo(HaveOccurred())
+				pipelineRunNames := []string{}
+				for _, plr := range listPipelineRuns(MintMakerNamespaceName) {
+					pipelineRunNames = append(pipelineRunNames, plr.Name)
+				}
+				Expect(pipelineRunNames).To(HaveLen(expectedPipelineRuns))
+
+				// Forget the status, as if the controller crashed before storing it
+				dependencyUpdateCheck := getDependencyUpdateCheck(crashedCheckKey)
+				dependencyUpdateCheck.Status = mmv1alpha1.DependencyUpdateCheckStatus{}
+				Expect(k8sClient.Status().Update(ctx, dependencyUpdateCheck)).Should(Succeed())
+
+				_, err = reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: crashedCheckKey})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(listPipelineRuns(MintMakerNamespaceName)).To(HaveLen(expectedPipelineRuns))
+				dependencyUpdateCheck = getDependencyUpdateCheck(crashedCheckKey)
+				Expect(dependencyUpdateCheck.Status.ScheduledTargets).To(BeEquivalentTo(expectedPipelineRuns))
+				for _, target := range dependencyUpdateCheck.Status.Targets {
+					Expect(pipelineRunNames).To(ContainElement(target.PipelineRun))
+				}
+				deleteDependencyUpdateCheck(crashedCheckKey)
+			})
+
+			It("should process the pending targets in batches and resume from the stored progress", func() {
+				// Checks outside the mintmaker namespace are ignored by the controller
+				// of the suite, so only the batching reconciler processes this one
+				batchedCheckKey := types.NamespacedName{Namespace: "default", Name: dependencyUpdateCheckName}
+				reconciler := &DependencyUpdateCheckReconciler{Client: k8sClient, Scheme: k8sClient.Scheme(), TargetBatchSize: 1, TargetWorkers: 2}
+				createDependencyUpdateCheckWithSpec(batchedCheckKey, mmv1alpha1.DependencyUpdateCheckSpec{
+					Repositories: []mmv1alpha1.RepositorySpec{
+						{URL: "https://github.com/first.git"},
+						{URL: "https://github.com/second.git"},
+					},
+				})
+
+				result, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: batchedCheckKey})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.RequeueAfter).To(Equal(pendingTargetsBatchInterval))
+				Expect(listPipelineRuns(MintMakerNamespaceName)).To(HaveLen(expectedPipelineRuns))
+				dependencyUpdateCheck := getDependencyUpdateCheck(batchedCheckKey)
+				Expect(dependencyUpdateCheck.Status.Phase).To(Equal(mmv1alpha1.PhaseRunning))
+				Expect(dependencyUpdateCheck.Status.PendingTargets).To(BeEquivalentTo(1))
+				Expect(dependencyUpdateCheck.Status.ScheduledTargets).To(BeEquivalentTo(expectedPipelineRuns))
+				pendingTarget := dependencyUpdateCheck.Status.Targets[len(dependencyUpdateCheck.Status.Targets)-1]
+				Expect(pendingTarget.State).To(Equal(mmv1alpha1.TargetStatePending))
+				Expect(pendingTarget.URL).To(Equal("https://github.com/second.git"))
+
+				// A new reconciler, as if the controller restarted between the batches
+				reconciler = &DependencyUpdateCheckReconciler{Client: k8sClient, Scheme: k8sClient.Scheme(), TargetBatchSize: 1}
+				result, err = reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: batchedCheckKey})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.RequeueAfter).To(BeZero())
+				Expect(listPipelineRuns(MintMakerNamespaceName)).To(HaveLen(2 * expectedPipelineRuns))
+				dependencyUpdateCheck = getDependencyUpdateCheck(batchedCheckKey)
+				Expect(dependencyUpdateCheck.Status.Phase).To(Equal(mmv1alpha1.PhaseCompleted))
+				Expect(dependencyUpdateCheck.Status.PendingTargets).To(BeZero())
+				Expect(dependencyUpdateCheck.Status.ScheduledTargets).To(BeEquivalentTo(2 * expectedPipelineRuns))
+				Expect(dependencyUpdateCheck.Status.Targets[0].Repository).To(Equal("first"))
+				Expect(dependencyUpdateCheck.Status.Targets[len(dependencyUpdateCheck.Status.Targets)-1].Repository).To(Equal("second"))
+				deleteDependencyUpdateCheck(batchedCheckKey)
+			})
+
+			It("should queue the targets until their time when the pipelineruns are spread over a time window", func() {
+				createDependencyUpdateCheckWithSpec(dependencyUpdateCheckKey, mmv1alpha1.DependencyUpdateCheckSpec{
+					SpreadOver: &metav1.Duration{Duration: 365 * 24 * time.Hour},
+				})
+				Eventually(func(g Gomega) {
+					dependencyUpdateCheck := getDependencyUpdateCheck(dependencyUpdateCheckKey)
+					g.Expect(dependencyUpdateCheck.Status.QueuedTargets).To(BeEquivalentTo(expectedPipelineRuns))
+					g.Expect(dependencyUpdateCheck.Status.Phase).To(Equal(mmv1alpha1.PhaseRunning))
+					notBefore := dependencyUpdateCheck.Status.Targets[0].NotBefore
+					g.Expect(notBefore).NotTo(BeNil())
+					g.Expect(notBefore.Time).To(BeTemporally(">=", dependencyUpdateCheck.Status.StartTime.Time))
+					// All branches of the repository are due at the same time
+					for _, target := range dependencyUpdateCheck.Status.Targets {
+						g.Expect(target.State).To(Equal(mmv1alpha1.TargetStateQueued))
+						g.Expect(target.NotBefore).To(Equal(notBefore))
+					}
+				}, timeout, interval).Should(Succeed())
+				Expect(listPipelineRuns(MintMakerNamespaceName)).To(BeEmpty())
+				deleteDependencyUpdateCheck(dependencyUpdateCheckKey)
+			})
+
 			It("should not create a pipelinerun if the DependencyUpdateCheck CR has been processed before", func() {
 				// Create a DependencyUpdateCheck CR in "mintmaker" namespace, that was processed before
 				createDependencyUpdateCheck(dependencyUpdateCheckKey, true, nil)
-				Eventually(listPipelineRuns).WithArguments(MintMakerNamespaceName).Should(HaveLen(0))
+				Eventually(func() mmv1alpha1.DependencyUpdateCheckPhase {
+					return getDependencyUpdateCheck(dependencyUpdateCheckKey).Status.Phase
+				}, timeout, interval).Should(Equal(mmv1alpha1.PhaseCompleted))
+				Expect(listPipelineRuns(MintMakerNamespaceName)).Should(HaveLen(0))
 				deleteDependencyUpdateCheck(dependencyUpdateCheckKey)
 			})
 
@@ -260,4 +793,353 @@ var _ = Describe("DependencyUpdateCheck Controller", func() {
 			})
 		})
 	}
+
+	Context("When naming pipelineruns", func() {
+
+		check := &mmv1alpha1.DependencyUpdateCheck{ObjectMeta: metav1.ObjectMeta{UID: "check-uid", Generation: 1}}
+		target := mmv1alpha1.TargetStatus{Host: "github.com", Repository: "konflux-ci/mintmaker", Branch: "main"}
+
+		It("should derive the same name for the same attempt of a target", func() {
+			name := pipelineRunName(check, target, 1)
+			Expect(pipelineRunName(check, target, 1)).To(Equal(name))
+			Expect(name).To(HavePrefix("renovate-"))
+			Expect(len(name)).To(BeNumerically("<=", 63))
+		})
+
+		It("should derive different names for other attempts, branches and generations", func() {
+			name := pipelineRunName(check, target, 1)
+			Expect(pipelineRunName(check, target, 2)).NotTo(Equal(name))
+
+			otherBranch := target
+			otherBranch.Branch = "release-1.0"
+			Expect(pipelineRunName(check, otherBranch, 1)).NotTo(Equal(name))
+
+			rerun := check.DeepCopy()
+			rerun.Generation = 2
+			Expect(pipelineRunName(rerun, target, 1)).NotTo(Equal(name))
+		})
+	})
+
+	Context("When looking for a running pipelinerun of a branch", func() {
+
+		It("should tell apart branches whose labels are truncated to the same value", func() {
+			prefix := strings.Repeat("release-", 8)
+			comp := &gitlab.Component{BaseComponent: base.BaseComponent{
+				Host:       "gitlab.com",
+				GitURL:     "https://gitlab.com/org/repo",
+				Repository: "org/repo",
+			}}
+			newPipelineRun := func(name, branch string) *tektonv1.PipelineRun {
+				return &tektonv1.PipelineRun{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      name,
+						Namespace: MintMakerNamespaceName,
+						Labels: map[string]string{
+							"mintmaker.appstudio.redhat.com/git-host":   comp.GetHost(),
+							"mintmaker.appstudio.redhat.com/repository": utils.NormalizeLabelValue(comp.GetRepository()),
+							"mintmaker.appstudio.redhat.com/branch":     utils.NormalizeLabelValue(branch),
+						},
+						Annotations: map[string]string{
+							MintMakerGitURLAnnotationName: comp.GetGitURL(),
+							MintMakerBranchAnnotationName: branch,
+						},
+					},
+				}
+			}
+			Expect(utils.NormalizeLabelValue(prefix + "1")).To(Equal(utils.NormalizeLabelValue(prefix + "2")))
+
+			scheme := runtime.NewScheme()
+			Expect(tektonv1.AddToScheme(scheme)).To(Succeed())
+			reconciler := &DependencyUpdateCheckReconciler{
+				Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(newPipelineRun("renovate-1", prefix+"1")).Build(),
+			}
+
+			running, err := reconciler.getRunningPipelineRun(ctx, comp, prefix+"1")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(running).To(Equal("renovate-1"))
+
+			running, err = reconciler.getRunningPipelineRun(ctx, comp, prefix+"2")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(running).To(BeEmpty())
+		})
+	})
+
+	Context("When counting the capacity for pipelineruns", func() {
+
+		It("should count the created pipelineruns which aren't listed yet", func() {
+			scheme := runtime.NewScheme()
+			Expect(tektonv1.AddToScheme(scheme)).To(Succeed())
+			reconciler := &DependencyUpdateCheckReconciler{
+				Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(&tektonv1.PipelineRun{
+					ObjectMeta: metav1.ObjectMeta{Name: "renovate-1", Namespace: MintMakerNamespaceName},
+				}).Build(),
+				MaxConcurrentPipelineRuns: 3,
+			}
+			reconciler.createdPipelineRuns.add("renovate-1")
+			reconciler.createdPipelineRuns.add("renovate-2")
+
+			capacity, err := reconciler.pipelineRunCapacity(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(capacity).To(Equal(1))
+			Expect(reconciler.createdPipelineRuns.names).To(HaveKey("renovate-2"))
+			Expect(reconciler.createdPipelineRuns.names).NotTo(HaveKey("renovate-1"))
+
+			reconciler.createdPipelineRuns.names["renovate-2"] = time.Now().Add(-createdPipelineRunsTTL - time.Second)
+			capacity, err = reconciler.pipelineRunCapacity(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(capacity).To(Equal(2))
+			Expect(reconciler.createdPipelineRuns.names).To(BeEmpty())
+		})
+	})
+
+	Context("When writing the resources of a pipelinerun left over from an earlier attempt", func() {
+
+		It("should keep the owner references and the data written by others", func() {
+			ownerReference := metav1.OwnerReference{APIVersion: "tekton.dev/v1", Kind: "PipelineRun", Name: "renovate-1", UID: "plr-uid"}
+			scheme := runtime.NewScheme()
+			Expect(corev1.AddToScheme(scheme)).To(Succeed())
+			reconciler := &DependencyUpdateCheckReconciler{
+				Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:            "renovate-1",
+						Namespace:       MintMakerNamespaceName,
+						OwnerReferences: []metav1.OwnerReference{ownerReference},
+					},
+					Data: map[string][]byte{"renovate-token": []byte("token"), "config": []byte("old")},
+				}).Build(),
+			}
+
+			Expect(reconciler.createOrUpdate(ctx, &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "renovate-1", Namespace: MintMakerNamespaceName},
+				Data:       map[string][]byte{"config": []byte("new")},
+			})).To(Succeed())
+
+			secret := &corev1.Secret{}
+			Expect(reconciler.Client.Get(ctx, types.NamespacedName{Name: "renovate-1", Namespace: MintMakerNamespaceName}, secret)).To(Succeed())
+			Expect(secret.OwnerReferences).To(Equal([]metav1.OwnerReference{ownerReference}))
+			Expect(secret.Data).To(Equal(map[string][]byte{"renovate-token": []byte("token"), "config": []byte("new")}))
+		})
+	})
+
+	Context("When matching repositories against the exclude patterns", func() {
+
+		It("should match Bitbucket repositories by project and name, whatever the URL", func() {
+			exclude := &mmv1alpha1.ExcludeSpec{Repositories: []string{"bitbucket.example.com/PROJ/*"}}
+			for _, gitURL := range []string{
+				"https://bitbucket.example.com/scm/PROJ/repo.git",
+				"git@bitbucket.example.com:PROJ/repo.git",
+				"https://bitbucket.example.com/projects/PROJ/repos/repo/browse",
+			} {
+				Expect(getRepositoryExcludeRule(gitURL, exclude)).To(Equal("repository bitbucket.example.com/PROJ/*"), gitURL)
+			}
+			Expect(getRepositoryExcludeRule("https://bitbucket.example.com/scm/PROJ/repo.git",
+				&mmv1alpha1.ExcludeSpec{Repositories: []string{"bitbucket.example.com/scm/*"}})).To(BeEmpty())
+		})
+	})
+
+	Context("When compacting the targets of a check", func() {
+
+		It("should only keep the first skipped targets and count the others", func() {
+			status := &mmv1alpha1.DependencyUpdateCheckStatus{}
+			for i := 0; i < maxSkippedTargets+2; i++ {
+				status.Targets = append(status.Targets,
+					mmv1alpha1.TargetStatus{Component: fmt.Sprintf("comp-%d", i), State: mmv1alpha1.TargetStateSkipped, Reason: mmv1alpha1.TargetReasonDisabled},
+					mmv1alpha1.TargetStatus{Component: fmt.Sprintf("scheduled-%d", i), State: mmv1alpha1.TargetStateScheduled})
+			}
+
+			settleRun(status)
+			Expect(status.Targets).To(HaveLen(2*maxSkippedTargets + 2))
+			Expect(status.Targets[len(status.Targets)-1].Component).To(Equal(fmt.Sprintf("scheduled-%d", maxSkippedTargets+1)))
+			Expect(status.CompactedTargets).To(Equal(map[string]int32{mmv1alpha1.TargetReasonDisabled: 2}))
+			Expect(status.TotalTargets).To(BeEquivalentTo(2*maxSkippedTargets + 4))
+			Expect(status.SkippedTargets).To(BeEquivalentTo(maxSkippedTargets + 2))
+			Expect(status.ScheduledTargets).To(BeEquivalentTo(maxSkippedTargets + 2))
+
+			// Compacting again keeps the counts
+			settleRun(status)
+			Expect(status.CompactedTargets).To(Equal(map[string]int32{mmv1alpha1.TargetReasonDisabled: 2}))
+			Expect(status.TotalTargets).To(BeEquivalentTo(2*maxSkippedTargets + 4))
+		})
+	})
+
+	Context("When spreading pipelineruns over a time window", func() {
+
+		It("should give a repository the same offset in the window every time", func() {
+			window := 6 * time.Hour
+			offset := spreadOffset("github.com/konflux-ci/mintmaker", window)
+			Expect(spreadOffset("github.com/konflux-ci/mintmaker", window)).To(Equal(offset))
+			Expect(offset).To(BeNumerically(">=", 0))
+			Expect(offset).To(BeNumerically("<", window))
+		})
+
+		It("should spread the repositories over the whole window", func() {
+			window := 6 * time.Hour
+			hours := map[int]bool{}
+			for i := 0; i < 100; i++ {
+				hours[int(spreadOffset(fmt.Sprintf("github.com/org/repo-%d", i), window)/time.Hour)] = true
+			}
+			Expect(hours).To(HaveLen(6))
+		})
+	})
+
+	Context("When scheduling the scans of a component", func() {
+
+		lastScan := time.Date(2026, time.March, 4, 10, 0, 0, 0, time.UTC) // a Wednesday
+		scanTimes := map[string]time.Time{"github.com/org/repo@main": lastScan}
+
+		It("should accept the shorthands and cron expressions", func() {
+			for _, value := range []string{"daily", "weekly", "monthly", "0 3 * * 1"} {
+				_, err := parseScanSchedule(value)
+				Expect(err).NotTo(HaveOccurred(), value)
+			}
+		})
+
+		It("should reject invalid schedules", func() {
+			_, err := parseScanSchedule("fortnightly")
+			Expect(err).To(MatchError(ContainSubstring(MintMakerScheduleAnnotationName)))
+		})
+
+		It("should not scan a target again before its schedule is due", func() {
+			schedule, err := parseScanSchedule("weekly")
+			Expect(err).NotTo(HaveOccurred())
+			next := nextScan(schedule, scanTimes, "github.com/org/repo@main", lastScan.Add(24*time.Hour))
+			Expect(next).To(Equal(time.Date(2026, time.March, 8, 0, 0, 0, 0, time.UTC)))
+		})
+
+		It("should scan a target when its schedule is due", func() {
+			schedule, err := parseScanSchedule("weekly")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(nextScan(schedule, scanTimes, "github.com/org/repo@main", lastScan.Add(5*24*time.Hour)).IsZero()).To(BeTrue())
+		})
+
+		It("should scan targets which were never scanned", func() {
+			schedule, err := parseScanSchedule("monthly")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(nextScan(schedule, scanTimes, "github.com/org/repo@devel", lastScan).IsZero()).To(BeTrue())
+		})
+
+		It("should prune scan times older than the longest schedule", func() {
+			scheme := runtime.NewScheme()
+			Expect(corev1.AddToScheme(scheme)).To(Succeed())
+			reconciler := &DependencyUpdateCheckReconciler{Client: fake.NewClientBuilder().WithScheme(scheme).Build()}
+
+			Expect(reconciler.recordScanTimes(ctx, []string{"github.com/org/old@main"}, lastScan)).To(Succeed())
+			Expect(reconciler.recordScanTimes(ctx, []string{"github.com/org/recent@main"}, lastScan.Add(scanTimesRetention))).To(Succeed())
+			now := lastScan.Add(scanTimesRetention + time.Hour)
+			Expect(reconciler.recordScanTimes(ctx, []string{"github.com/org/repo@main"}, now)).To(Succeed())
+
+			recorded, err := reconciler.getScanTimes(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(recorded).To(Equal(map[string]time.Time{
+				"github.com/org/recent@main": lastScan.Add(scanTimesRetention),
+				"github.com/org/repo@main":   now,
+			}))
+		})
+	})
+
+	Context("When building the Renovate config overrides of a target", func() {
+
+		It("should pass the overrides of the check through", func() {
+			check := &mmv1alpha1.DependencyUpdateCheck{Spec: mmv1alpha1.DependencyUpdateCheckSpec{
+				RenovateOverrides: &runtime.RawExtension{Raw: []byte(`{"prConcurrentLimit":20}`)},
+			}}
+			overrides, err := renovateOverrides(check, mmv1alpha1.UpdateModeAll)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(overrides).To(MatchJSON(`{"prConcurrentLimit":20}`))
+		})
+
+		It("should layer the overrides of the check on top of the vulnerability-only config", func() {
+			check := &mmv1alpha1.DependencyUpdateCheck{Spec: mmv1alpha1.DependencyUpdateCheckSpec{
+				RenovateOverrides: &runtime.RawExtension{Raw: []byte(`{"packageRules":[{"matchManagers":["tekton"],"enabled":true}]}`)},
+			}}
+			overrides, err := renovateOverrides(check, mmv1alpha1.UpdateModeVulnerabilityOnly)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(overrides).To(MatchJSON(`{
+				"osvVulnerabilityAlerts": true,
+				"vulnerabilityAlerts": {"enabled": true},
+				"packageRules": [
+					{"matchPackageNames": ["*"], "enabled": false},
+					{"matchManagers": ["tekton"], "enabled": true}
+				]
+			}`))
+		})
+
+		It("should fail on invalid overrides in every mode", func() {
+			check := &mmv1alpha1.DependencyUpdateCheck{Spec: mmv1alpha1.DependencyUpdateCheckSpec{
+				RenovateOverrides: &runtime.RawExtension{Raw: []byte(`[]`)},
+			}}
+			for _, updateMode := range []mmv1alpha1.UpdateMode{mmv1alpha1.UpdateModeAll, mmv1alpha1.UpdateModeVulnerabilityOnly} {
+				_, err := renovateOverrides(check, updateMode)
+				Expect(err).To(HaveOccurred(), string(updateMode))
+			}
+		})
+	})
+
+	Context("When resolving whether mintmaker is disabled for a component", func() {
+
+		newResolver := func(objects ...client.Object) *optOutResolver {
+			return newOptOutResolver(fake.NewClientBuilder().WithScheme(k8sClient.Scheme()).WithObjects(objects...).Build())
+		}
+		namespace := func(annotations map[string]string) *corev1.Namespace {
+			return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "tenant", Annotations: annotations}}
+		}
+		application := func(annotations map[string]string) *appstudiov1alpha1.Application {
+			return &appstudiov1alpha1.Application{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "tenant", Annotations: annotations}}
+		}
+		component := func(annotations map[string]string) *appstudiov1alpha1.Component {
+			return &appstudiov1alpha1.Component{
+				ObjectMeta: metav1.ObjectMeta{Name: "comp", Namespace: "tenant", Annotations: annotations},
+				Spec:       appstudiov1alpha1.ComponentSpec{Application: "app"},
+			}
+		}
+		disabled := map[string]string{MintMakerDisabledAnnotationName: "true"}
+		enabled := map[string]string{MintMakerEnabledAnnotationName: "true"}
+
+		It("should not disable components without annotations", func() {
+			reason, _, err := newResolver(namespace(nil), application(nil)).disabledBy(ctx, component(nil))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reason).To(BeEmpty())
+		})
+
+		It("should inherit the annotation of the namespace", func() {
+			reason, disabledBy, err := newResolver(namespace(disabled), application(nil)).disabledBy(ctx, component(nil))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reason).To(Equal(mmv1alpha1.TargetReasonNamespaceDisabled))
+			Expect(disabledBy).To(Equal("Namespace tenant"))
+		})
+
+		It("should inherit the annotation of the application", func() {
+			reason, disabledBy, err := newResolver(namespace(nil), application(disabled)).disabledBy(ctx, component(nil))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reason).To(Equal(mmv1alpha1.TargetReasonApplicationDisabled))
+			Expect(disabledBy).To(Equal("Application app"))
+		})
+
+		It("should let the application override the namespace", func() {
+			reason, _, err := newResolver(namespace(disabled), application(enabled)).disabledBy(ctx, component(nil))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reason).To(BeEmpty())
+		})
+
+		It("should let the component override the application and the namespace", func() {
+			resolver := newResolver(namespace(disabled), application(disabled))
+			reason, _, err := resolver.disabledBy(ctx, component(enabled))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reason).To(BeEmpty())
+
+			reason, _, err = resolver.disabledBy(ctx, component(map[string]string{MintMakerDisabledAnnotationName: "false"}))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reason).To(BeEmpty())
+
+			reason, _, err = resolver.disabledBy(ctx, component(disabled))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reason).To(Equal(mmv1alpha1.TargetReasonDisabled))
+		})
+
+		It("should ignore applications and namespaces which don't exist", func() {
+			reason, _, err := newResolver().disabledBy(ctx, component(nil))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reason).To(BeEmpty())
+		})
+	})
 })
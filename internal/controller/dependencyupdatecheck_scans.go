@@ -0,0 +1,143 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+
+	. "github.com/konflux-ci/mintmaker/internal/constant"
+)
+
+const (
+	// scanTimesConfigMapName is the ConfigMap in the MintMaker namespace which
+	// records when PipelineRuns were last created for each repository+branch
+	scanTimesConfigMapName = "mintmaker-scan-times"
+	// scanTimesKey is the key of the ConfigMap holding the scan times as JSON
+	scanTimesKey = "scan-times.json"
+	// scanTimesRetention is how long scan times are recorded, the interval of
+	// the longest supported schedule, a yearly cron expression. Targets whose
+	// scan time was pruned are due again.
+	scanTimesRetention = 366 * 24 * time.Hour
+)
+
+// parseScanSchedule parses the value of the schedule annotation of a Component,
+// either daily, weekly, monthly or a standard cron expression
+func parseScanSchedule(value string) (cron.Schedule, error) {
+	spec := value
+	switch value {
+	case "daily", "weekly", "monthly":
+		spec = "@" + value
+	}
+	schedule, err := cron.ParseStandard(spec)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s annotation %q: %w", MintMakerScheduleAnnotationName, value, err)
+	}
+	return schedule, nil
+}
+
+// nextScan returns the time when the target is due according to the schedule,
+// or zero time if it's due now
+func nextScan(schedule cron.Schedule, scanTimes map[string]time.Time, key string, now time.Time) time.Time {
+	lastScan, ok := scanTimes[key]
+	if !ok {
+		return time.Time{}
+	}
+	if next := schedule.Next(lastScan); now.Before(next) {
+		return next
+	}
+	return time.Time{}
+}
+
+// getScanTimes returns the recorded scan times by repository+branch key
+func (r *DependencyUpdateCheckReconciler) getScanTimes(ctx context.Context) (map[string]time.Time, error) {
+	configMap := &corev1.ConfigMap{}
+	err := r.Client.Get(ctx, types.NamespacedName{Namespace: MintMakerNamespaceName, Name: scanTimesConfigMapName}, configMap)
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, err
+	}
+	return decodeScanTimes(configMap)
+}
+
+// decodeScanTimes returns the scan times stored in the ConfigMap
+func decodeScanTimes(configMap *corev1.ConfigMap) (map[string]time.Time, error) {
+	scanTimes := map[string]time.Time{}
+	if data := configMap.Data[scanTimesKey]; data != "" {
+		if err := json.Unmarshal([]byte(data), &scanTimes); err != nil {
+			return nil, fmt.Errorf("error unmarshaling scan times: %w", err)
+		}
+	}
+	return scanTimes, nil
+}
+
+// recordScanTimes stores the time of the scan of the repository+branch keys,
+// and prunes the scan times older than scanTimesRetention
+func (r *DependencyUpdateCheckReconciler) recordScanTimes(ctx context.Context, keys []string, scanTime time.Time) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		configMap := &corev1.ConfigMap{}
+		err := r.Client.Get(ctx, types.NamespacedName{Namespace: MintMakerNamespaceName, Name: scanTimesConfigMapName}, configMap)
+		exists := err == nil
+		if err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+
+		scanTimes, err := decodeScanTimes(configMap)
+		if err != nil {
+			return err
+		}
+		for key, lastScan := range scanTimes {
+			if scanTime.Sub(lastScan) > scanTimesRetention {
+				delete(scanTimes, key)
+			}
+		}
+		for _, key := range keys {
+			scanTimes[key] = scanTime.UTC()
+		}
+		data, err := json.Marshal(scanTimes)
+		if err != nil {
+			return err
+		}
+
+		if exists {
+			if configMap.Data == nil {
+				configMap.Data = map[string]string{}
+			}
+			configMap.Data[scanTimesKey] = string(data)
+			return r.Client.Update(ctx, configMap)
+		}
+		configMap = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: MintMakerNamespaceName, Name: scanTimesConfigMapName},
+			Data:       map[string]string{scanTimesKey: string(data)},
+		}
+		err = r.Client.Create(ctx, configMap)
+		if errors.IsAlreadyExists(err) {
+			// Another reconcile created it meanwhile, retry as a conflict
+			return errors.NewConflict(corev1.Resource("configmaps"), scanTimesConfigMapName, err)
+		}
+		return err
+	})
+}
@@ -0,0 +1,212 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	appstudiov1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
+
+	mmv1alpha1 "github.com/konflux-ci/mintmaker/api/v1alpha1"
+)
+
+// newComponentTarget returns a target pre-filled with the identity of the Component
+func newComponentTarget(comp *appstudiov1alpha1.Component) mmv1alpha1.TargetStatus {
+	return mmv1alpha1.TargetStatus{
+		Namespace:   comp.Namespace,
+		Application: comp.Spec.Application,
+		Component:   comp.Name,
+	}
+}
+
+// skipped returns a copy of the target marked as skipped for the given reason
+func skipped(target mmv1alpha1.TargetStatus, reason, message string) mmv1alpha1.TargetStatus {
+	target.State = mmv1alpha1.TargetStateSkipped
+	target.Reason = reason
+	target.Message = message
+	return target
+}
+
+// failed returns a copy of the target marked as failed for the given reason
+func failed(target mmv1alpha1.TargetStatus, reason string, err error) mmv1alpha1.TargetStatus {
+	target.State = mmv1alpha1.TargetStateFailed
+	target.Reason = reason
+	target.Message = err.Error()
+	return target
+}
+
+// scheduled returns a copy of the target marked as scheduled in the PipelineRun
+func scheduled(target mmv1alpha1.TargetStatus, pipelineRun string) mmv1alpha1.TargetStatus {
+	target.State = mmv1alpha1.TargetStateScheduled
+	target.PipelineRun = pipelineRun
+	target.Attempts++
+	return target
+}
+
+// startRun resets the status for processing the given generation of the check
+func startRun(status *mmv1alpha1.DependencyUpdateCheckStatus, generation int64) {
+	now := metav1.Now()
+	status.ObservedGeneration = generation
+	status.Phase = mmv1alpha1.PhaseRunning
+	status.StartTime = &now
+	status.CompletionTime = nil
+	status.CompactedTargets = nil
+	status.Targets = nil
+	updateStatusSummary(status)
+
+	meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:               mmv1alpha1.ConditionCompleted,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: generation,
+		Reason:             "Running",
+		Message:            "targets are being processed",
+	})
+	meta.RemoveStatusCondition(&status.Conditions, mmv1alpha1.ConditionDegraded)
+}
+
+// settleRun completes the run, unless some of its targets are pending or
+// queued. A run with queued targets stays running until all of them are scheduled.
+func settleRun(status *mmv1alpha1.DependencyUpdateCheckStatus) {
+	compactTargets(status)
+	updateStatusSummary(status)
+	if status.PendingTargets > 0 {
+		meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               mmv1alpha1.ConditionCompleted,
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: status.ObservedGeneration,
+			Reason:             "Running",
+			Message:            fmt.Sprintf("%d of %d targets pending", status.PendingTargets, status.TotalTargets),
+		})
+		return
+	}
+	if status.QueuedTargets == 0 {
+		completeRun(status)
+		return
+	}
+
+	meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:               mmv1alpha1.ConditionCompleted,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: status.ObservedGeneration,
+		Reason:             "Queued",
+		Message: fmt.Sprintf("%d of %d targets queued, waiting for their time or for running PipelineRuns to finish",
+			status.QueuedTargets, status.TotalTargets),
+	})
+}
+
+// completeRun marks the run of the observed generation as finished and sets
+// its phase and conditions from the outcome of the targets.
+func completeRun(status *mmv1alpha1.DependencyUpdateCheckStatus) {
+	updateStatusSummary(status)
+
+	now := metav1.Now()
+	status.CompletionTime = &now
+	status.Phase = mmv1alpha1.PhaseCompleted
+	if status.FailedTargets > 0 {
+		status.Phase = mmv1alpha1.PhaseFailed
+	}
+
+	message := fmt.Sprintf("%d of %d targets scheduled, %d failed, %d skipped",
+		status.ScheduledTargets, status.TotalTargets, status.FailedTargets, status.SkippedTargets)
+	if status.PlannedTargets > 0 {
+		message = fmt.Sprintf("%d of %d targets planned, %d failed, %d skipped",
+			status.PlannedTargets, status.TotalTargets, status.FailedTargets, status.SkippedTargets)
+	}
+	meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:               mmv1alpha1.ConditionCompleted,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: status.ObservedGeneration,
+		Reason:             "Processed",
+		Message:            message,
+	})
+
+	degraded := metav1.Condition{
+		Type:               mmv1alpha1.ConditionDegraded,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: status.ObservedGeneration,
+		Reason:             "NoFailures",
+		Message:            "no target failed",
+	}
+	if status.FailedTargets > 0 {
+		degraded.Status = metav1.ConditionTrue
+		degraded.Reason = "TargetsFailed"
+		degraded.Message = fmt.Sprintf("%d targets failed, see status.targets for details", status.FailedTargets)
+	}
+	meta.SetStatusCondition(&status.Conditions, degraded)
+}
+
+// compactTargets drops the skipped targets beyond the first maxSkippedTargets
+// from the targets and counts them by reason in status.compactedTargets.
+// Skipped targets are never processed again, unlike the other finished ones
+// which are needed for retrying their PipelineRuns.
+func compactTargets(status *mmv1alpha1.DependencyUpdateCheckStatus) {
+	skippedTargets := 0
+	targets := status.Targets[:0]
+	for _, target := range status.Targets {
+		if target.State == mmv1alpha1.TargetStateSkipped {
+			skippedTargets++
+			if skippedTargets > maxSkippedTargets {
+				if status.CompactedTargets == nil {
+					status.CompactedTargets = map[string]int32{}
+				}
+				status.CompactedTargets[target.Reason]++
+				continue
+			}
+		}
+		targets = append(targets, target)
+	}
+	status.Targets = targets
+}
+
+// updateStatusSummary recomputes the aggregate counters of the status from its
+// targets and the compacted ones
+func updateStatusSummary(status *mmv1alpha1.DependencyUpdateCheckStatus) {
+	status.TotalTargets = int32(len(status.Targets))
+	status.ScheduledTargets = 0
+	status.PlannedTargets = 0
+	status.PendingTargets = 0
+	status.QueuedTargets = 0
+	status.FailedTargets = 0
+	status.SkippedTargets = 0
+	for _, count := range status.CompactedTargets {
+		status.TotalTargets += count
+		status.SkippedTargets += count
+	}
+	for _, target := range status.Targets {
+		switch target.State {
+		case mmv1alpha1.TargetStateScheduled:
+			status.ScheduledTargets++
+		case mmv1alpha1.TargetStatePlanned:
+			status.PlannedTargets++
+		case mmv1alpha1.TargetStatePending:
+			status.PendingTargets++
+		case mmv1alpha1.TargetStateQueued:
+			status.QueuedTargets++
+		case mmv1alpha1.TargetStateFailed:
+			status.FailedTargets++
+		case mmv1alpha1.TargetStateSkipped:
+			status.SkippedTargets++
+		}
+	}
+}
+
+// targetKey returns the repository+branch key identifying the target,
+// the controller creates at most one PipelineRun per key
+func targetKey(target mmv1alpha1.TargetStatus) string {
+	return fmt.Sprintf("%s/%s@%s", target.Host, target.Repository, target.Branch)
+}
@@ -0,0 +1,332 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ref "k8s.io/client-go/tools/reference"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
+
+	mmv1alpha1 "github.com/konflux-ci/mintmaker/api/v1alpha1"
+	. "github.com/konflux-ci/mintmaker/internal/constant"
+)
+
+// The maximum number of missed runs which are counted before giving up,
+// to avoid iterating over the schedule forever after a long outage
+const maxMissedScheduleRuns = 100
+
+// Clock knows how to get the current time, it is replaced in tests
+type Clock interface {
+	Now() time.Time
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time { return time.Now() }
+
+// DependencyUpdateScheduleReconciler reconciles a DependencyUpdateSchedule object
+type DependencyUpdateScheduleReconciler struct {
+	Client client.Client
+	Scheme *runtime.Scheme
+	Clock  Clock
+}
+
+// +kubebuilder:rbac:groups=appstudio.redhat.com,resources=dependencyupdateschedules,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=appstudio.redhat.com,resources=dependencyupdateschedules/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=appstudio.redhat.com,resources=dependencyupdateschedules/finalizers,verbs=update
+// +kubebuilder:rbac:groups=appstudio.redhat.com,resources=dependencyupdatechecks,verbs=get;list;watch;create;update;patch;delete
+
+// Reconcile creates a DependencyUpdateCheck from the template whenever the schedule
+// is due, keeps track of the running checks and removes old finished checks.
+func (r *DependencyUpdateScheduleReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := ctrllog.FromContext(ctx).WithName("DependencyUpdateScheduleController")
+	ctx = ctrllog.IntoContext(ctx, log)
+
+	schedule := &mmv1alpha1.DependencyUpdateSchedule{}
+	if err := r.Client.Get(ctx, req.NamespacedName, schedule); err != nil {
+		if errors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		log.Error(err, "failed to get DependencyUpdateSchedule")
+		return ctrl.Result{}, err
+	}
+
+	checkList := &mmv1alpha1.DependencyUpdateCheckList{}
+	if err := r.Client.List(ctx, checkList, client.InNamespace(req.Namespace), client.MatchingLabels{MintMakerScheduleLabelName: req.Name}); err != nil {
+		log.Error(err, "failed to list DependencyUpdateChecks")
+		return ctrl.Result{}, err
+	}
+
+	var activeChecks, successfulChecks, failedChecks []*mmv1alpha1.DependencyUpdateCheck
+	var mostRecentTime *time.Time
+	for i := range checkList.Items {
+		check := &checkList.Items[i]
+		switch getCheckFinishedPhase(check) {
+		case "":
+			activeChecks = append(activeChecks, check)
+		case mmv1alpha1.PhaseCompleted:
+			successfulChecks = append(successfulChecks, check)
+		case mmv1alpha1.PhaseFailed:
+			failedChecks = append(failedChecks, check)
+		}
+
+		scheduledTime, err := getScheduledTimeForCheck(check)
+		if err != nil {
+			log.Error(err, "unable to parse schedule time for DependencyUpdateCheck", "check", check.Name)
+			continue
+		}
+		if scheduledTime != nil && (mostRecentTime == nil || mostRecentTime.Before(*scheduledTime)) {
+			mostRecentTime = scheduledTime
+		}
+	}
+
+	// The last schedule time only ever moves forward, checks of past runs may
+	// have been pruned or deleted, which must not make their runs due again
+	if mostRecentTime != nil && (schedule.Status.LastScheduleTime == nil || schedule.Status.LastScheduleTime.Time.Before(*mostRecentTime)) {
+		schedule.Status.LastScheduleTime = &metav1.Time{Time: *mostRecentTime}
+	}
+	for _, check := range successfulChecks {
+		completionTime := check.Status.CompletionTime
+		if completionTime != nil && (schedule.Status.LastSuccessfulTime == nil || schedule.Status.LastSuccessfulTime.Before(completionTime)) {
+			schedule.Status.LastSuccessfulTime = completionTime
+		}
+	}
+	schedule.Status.Active = nil
+	for _, check := range activeChecks {
+		checkRef, err := ref.GetReference(r.Scheme, check)
+		if err != nil {
+			log.Error(err, "unable to make reference to active DependencyUpdateCheck", "check", check.Name)
+			continue
+		}
+		schedule.Status.Active = append(schedule.Status.Active, *checkRef)
+	}
+
+	if err := r.Client.Status().Update(ctx, schedule); err != nil {
+		log.Error(err, "failed to update DependencyUpdateSchedule status")
+		return ctrl.Result{}, err
+	}
+
+	// Remove old finished checks, keeping the configured number of the most recent ones
+	if schedule.Spec.FailedChecksHistoryLimit != nil {
+		r.pruneChecks(ctx, failedChecks, int(*schedule.Spec.FailedChecksHistoryLimit))
+	}
+	if schedule.Spec.SuccessfulChecksHistoryLimit != nil {
+		r.pruneChecks(ctx, successfulChecks, int(*schedule.Spec.SuccessfulChecksHistoryLimit))
+	}
+
+	if schedule.Spec.Suspend != nil && *schedule.Spec.Suspend {
+		log.Info("DependencyUpdateSchedule is suspended, skipping")
+		return ctrl.Result{}, nil
+	}
+
+	now := r.now()
+	missedRun, nextRun, err := getNextSchedule(schedule, now)
+	if err != nil {
+		// The schedule won't become valid until the spec is changed, which triggers a new reconcile
+		log.Error(err, "unable to figure out DependencyUpdateSchedule schedule")
+		return ctrl.Result{}, nil
+	}
+
+	scheduledResult := ctrl.Result{RequeueAfter: nextRun.Sub(now)}
+	log = log.WithValues("now", now, "nextRun", nextRun)
+
+	if missedRun.IsZero() {
+		log.Info("no upcoming scheduled times, sleeping until next")
+		return scheduledResult, nil
+	}
+
+	log = log.WithValues("currentRun", missedRun)
+	tooLate := false
+	if schedule.Spec.StartingDeadlineSeconds != nil {
+		tooLate = missedRun.Add(time.Duration(*schedule.Spec.StartingDeadlineSeconds) * time.Second).Before(now)
+	}
+	if tooLate {
+		log.Info("missed starting deadline for last run, sleeping until next")
+		return scheduledResult, nil
+	}
+
+	if schedule.Spec.ConcurrencyPolicy == mmv1alpha1.ForbidConcurrent && len(activeChecks) > 0 {
+		log.Info("concurrency policy blocks concurrent runs, skipping", "active", len(activeChecks))
+		return scheduledResult, nil
+	}
+	if schedule.Spec.ConcurrencyPolicy == mmv1alpha1.ReplaceConcurrent {
+		for _, check := range activeChecks {
+			if err := r.Client.Delete(ctx, check, client.PropagationPolicy(metav1.DeletePropagationBackground)); client.IgnoreNotFound(err) != nil {
+				log.Error(err, "unable to delete active DependencyUpdateCheck", "check", check.Name)
+				return ctrl.Result{}, err
+			}
+		}
+	}
+
+	check, err := r.constructCheckForSchedule(schedule, missedRun)
+	if err != nil {
+		log.Error(err, "unable to construct DependencyUpdateCheck from template")
+		return scheduledResult, nil
+	}
+	if err := r.Client.Create(ctx, check); err != nil {
+		if !errors.IsAlreadyExists(err) {
+			log.Error(err, "unable to create DependencyUpdateCheck for DependencyUpdateSchedule", "check", check.Name)
+			return ctrl.Result{}, err
+		}
+		// The check for this run has been created before
+	} else {
+		log.Info("created DependencyUpdateCheck for DependencyUpdateSchedule run", "check", check.Name)
+	}
+
+	// Record the run right away, like CronJobs do, so it's never run again
+	// even if its check is deleted before the next reconcile
+	schedule.Status.LastScheduleTime = &metav1.Time{Time: missedRun}
+	if err := r.Client.Status().Update(ctx, schedule); err != nil {
+		log.Error(err, "failed to update DependencyUpdateSchedule status")
+		return ctrl.Result{}, err
+	}
+
+	return scheduledResult, nil
+}
+
+func (r *DependencyUpdateScheduleReconciler) now() time.Time {
+	if r.Clock == nil {
+		return realClock{}.Now()
+	}
+	return r.Clock.Now()
+}
+
+// pruneChecks deletes the oldest checks so that at most limit of them is kept
+func (r *DependencyUpdateScheduleReconciler) pruneChecks(ctx context.Context, checks []*mmv1alpha1.DependencyUpdateCheck, limit int) {
+	log := ctrllog.FromContext(ctx)
+
+	sort.Slice(checks, func(i, j int) bool {
+		return checks[i].CreationTimestamp.Before(&checks[j].CreationTimestamp)
+	})
+	for i, check := range checks {
+		if len(checks)-i <= limit {
+			break
+		}
+		if err := r.Client.Delete(ctx, check, client.PropagationPolicy(metav1.DeletePropagationBackground)); client.IgnoreNotFound(err) != nil {
+			log.Error(err, "unable to delete old DependencyUpdateCheck", "check", check.Name)
+		} else {
+			log.Info("deleted old DependencyUpdateCheck", "check", check.Name)
+		}
+	}
+}
+
+// constructCheckForSchedule returns the DependencyUpdateCheck for the run of the schedule at the given time.
+// The name is derived from the time, so that each run creates at most one check.
+func (r *DependencyUpdateScheduleReconciler) constructCheckForSchedule(schedule *mmv1alpha1.DependencyUpdateSchedule, scheduledTime time.Time) (*mmv1alpha1.DependencyUpdateCheck, error) {
+	check := &mmv1alpha1.DependencyUpdateCheck{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-%d", schedule.Name, scheduledTime.Unix()),
+			Namespace: schedule.Namespace,
+			Labels: map[string]string{
+				MintMakerScheduleLabelName: schedule.Name,
+			},
+			Annotations: map[string]string{
+				MintMakerScheduledAtAnnotationName: scheduledTime.Format(time.RFC3339),
+			},
+		},
+		Spec: *schedule.Spec.CheckTemplate.Spec.DeepCopy(),
+	}
+	if err := controllerutil.SetControllerReference(schedule, check, r.Scheme); err != nil {
+		return nil, err
+	}
+	return check, nil
+}
+
+// getCheckFinishedPhase returns the phase of a finished check, or an empty string if the check is still running
+func getCheckFinishedPhase(check *mmv1alpha1.DependencyUpdateCheck) mmv1alpha1.DependencyUpdateCheckPhase {
+	if check.Status.ObservedGeneration != check.Generation {
+		return ""
+	}
+	if check.Status.Phase == mmv1alpha1.PhaseCompleted || check.Status.Phase == mmv1alpha1.PhaseFailed {
+		return check.Status.Phase
+	}
+	return ""
+}
+
+// getScheduledTimeForCheck returns the time the check was scheduled for, stored in an annotation by the controller
+func getScheduledTimeForCheck(check *mmv1alpha1.DependencyUpdateCheck) (*time.Time, error) {
+	timeRaw := check.Annotations[MintMakerScheduledAtAnnotationName]
+	if len(timeRaw) == 0 {
+		return nil, nil
+	}
+	timeParsed, err := time.Parse(time.RFC3339, timeRaw)
+	if err != nil {
+		return nil, err
+	}
+	return &timeParsed, nil
+}
+
+// getNextSchedule returns the time of the last missed run, or zero time if no run has been
+// missed since the last one, and the time of the next run of the schedule.
+func getNextSchedule(schedule *mmv1alpha1.DependencyUpdateSchedule, now time.Time) (lastMissed time.Time, next time.Time, err error) {
+	spec := schedule.Spec.Schedule
+	if schedule.Spec.TimeZone != nil {
+		if _, err := time.LoadLocation(*schedule.Spec.TimeZone); err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("unknown time zone %q: %w", *schedule.Spec.TimeZone, err)
+		}
+		spec = fmt.Sprintf("CRON_TZ=%s %s", *schedule.Spec.TimeZone, spec)
+	}
+	sched, err := cron.ParseStandard(spec)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("unparseable schedule %q: %w", schedule.Spec.Schedule, err)
+	}
+
+	var earliestTime time.Time
+	if schedule.Status.LastScheduleTime != nil {
+		earliestTime = schedule.Status.LastScheduleTime.Time
+	} else {
+		earliestTime = schedule.CreationTimestamp.Time
+	}
+	if schedule.Spec.StartingDeadlineSeconds != nil {
+		// Runs which missed the deadline don't need to be counted
+		schedulingDeadline := now.Add(-time.Second * time.Duration(*schedule.Spec.StartingDeadlineSeconds))
+		if schedulingDeadline.After(earliestTime) {
+			earliestTime = schedulingDeadline
+		}
+	}
+	if earliestTime.After(now) {
+		return time.Time{}, sched.Next(now), nil
+	}
+
+	starts := 0
+	for t := sched.Next(earliestTime); !t.After(now); t = sched.Next(t) {
+		lastMissed = t
+		starts++
+		if starts > maxMissedScheduleRuns {
+			return time.Time{}, time.Time{}, fmt.Errorf("too many missed start times (> %d), set or decrease startingDeadlineSeconds or check clock skew", maxMissedScheduleRuns)
+		}
+	}
+	return lastMissed, sched.Next(now), nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *DependencyUpdateScheduleReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// Namespace filtering is handled by the manager's cache configuration.
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&mmv1alpha1.DependencyUpdateSchedule{}).
+		Owns(&mmv1alpha1.DependencyUpdateCheck{}).
+		Complete(r)
+}
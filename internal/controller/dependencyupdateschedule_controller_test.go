@@ -0,0 +1,196 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	mmv1alpha1 "github.com/konflux-ci/mintmaker/api/v1alpha1"
+	. "github.com/konflux-ci/mintmaker/internal/constant"
+)
+
+// testClock returns the current time shifted by an offset, so that tests
+// don't have to wait for the schedule to become due
+type testClock struct {
+	offset atomic.Int64
+}
+
+func (c *testClock) Now() time.Time {
+	return time.Now().Add(time.Duration(c.offset.Load()))
+}
+
+var scheduleClock = &testClock{}
+
+var _ = Describe("DependencyUpdateSchedule Controller", func() {
+
+	const scheduleName = "dependencyupdateschedule-sample"
+
+	scheduleKey := types.NamespacedName{Namespace: MintMakerNamespaceName, Name: scheduleName}
+
+	_ = BeforeEach(func() {
+		createNamespace(MintMakerNamespaceName)
+	})
+
+	_ = AfterEach(func() {
+		scheduleClock.offset.Store(0)
+		deleteDependencyUpdateSchedule(scheduleKey)
+		Expect(k8sClient.DeleteAllOf(ctx, &mmv1alpha1.DependencyUpdateCheck{}, client.InNamespace(MintMakerNamespaceName),
+			client.MatchingLabels{MintMakerScheduleLabelName: scheduleName})).Should(Succeed())
+	})
+
+	It("should create a DependencyUpdateCheck from the template when the schedule is due", func() {
+		scheduleClock.offset.Store(int64(2 * time.Minute))
+		createDependencyUpdateSchedule(scheduleKey, "* * * * *", false, mmv1alpha1.DependencyUpdateCheckSpec{RerunToken: "template"})
+
+		Eventually(listScheduledChecks).WithArguments(scheduleName).Should(HaveLen(1))
+		check := listScheduledChecks(scheduleName)[0]
+		Expect(check.Spec.RerunToken).To(Equal("template"))
+		Expect(check.Annotations).To(HaveKey(MintMakerScheduledAtAnnotationName))
+		Expect(metav1.IsControlledBy(&check, getDependencyUpdateSchedule(scheduleKey))).To(BeTrue())
+
+		Eventually(func() *metav1.Time {
+			return getDependencyUpdateSchedule(scheduleKey).Status.LastScheduleTime
+		}, timeout, interval).ShouldNot(BeNil())
+	})
+
+	It("should not create a DependencyUpdateCheck when the schedule is suspended", func() {
+		scheduleClock.offset.Store(int64(2 * time.Minute))
+		createDependencyUpdateSchedule(scheduleKey, "* * * * *", true, mmv1alpha1.DependencyUpdateCheckSpec{})
+
+		Consistently(listScheduledChecks).WithArguments(scheduleName).Should(BeEmpty())
+	})
+
+	Context("When computing the next run of a schedule", func() {
+
+		created := time.Date(2026, time.January, 1, 10, 0, 0, 0, time.UTC)
+
+		newSchedule := func(cron string) *mmv1alpha1.DependencyUpdateSchedule {
+			return &mmv1alpha1.DependencyUpdateSchedule{
+				ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(created)},
+				Spec:       mmv1alpha1.DependencyUpdateScheduleSpec{Schedule: cron},
+			}
+		}
+
+		It("should not report a missed run before the first scheduled time", func() {
+			missed, next, err := getNextSchedule(newSchedule("0 12 * * *"), created.Add(time.Hour))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(missed.IsZero()).To(BeTrue())
+			Expect(next).To(Equal(created.Add(2 * time.Hour)))
+		})
+
+		It("should report the last missed run", func() {
+			missed, next, err := getNextSchedule(newSchedule("0 * * * *"), created.Add(150*time.Minute))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(missed).To(Equal(created.Add(2 * time.Hour)))
+			Expect(next).To(Equal(created.Add(3 * time.Hour)))
+		})
+
+		It("should count missed runs from the last schedule time", func() {
+			schedule := newSchedule("0 * * * *")
+			schedule.Status.LastScheduleTime = &metav1.Time{Time: created.Add(2 * time.Hour)}
+			missed, _, err := getNextSchedule(schedule, created.Add(150*time.Minute))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(missed.IsZero()).To(BeTrue())
+		})
+
+		It("should use the time zone of the schedule", func() {
+			schedule := newSchedule("0 12 * * *")
+			schedule.Spec.TimeZone = ptr.To("Europe/Prague")
+			_, next, err := getNextSchedule(schedule, created)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(next.UTC()).To(Equal(time.Date(2026, time.January, 1, 11, 0, 0, 0, time.UTC)))
+		})
+
+		It("should fail when too many runs were missed", func() {
+			_, _, err := getNextSchedule(newSchedule("* * * * *"), created.Add(24*time.Hour))
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should only count runs within the starting deadline", func() {
+			schedule := newSchedule("* * * * *")
+			schedule.Spec.StartingDeadlineSeconds = ptr.To(int64(300))
+			missed, _, err := getNextSchedule(schedule, created.Add(24*time.Hour))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(missed).To(Equal(created.Add(24 * time.Hour)))
+		})
+
+		It("should fail on an invalid schedule or time zone", func() {
+			_, _, err := getNextSchedule(newSchedule("not a schedule"), created)
+			Expect(err).To(HaveOccurred())
+
+			schedule := newSchedule("0 12 * * *")
+			schedule.Spec.TimeZone = ptr.To("Mars/Olympus")
+			_, _, err = getNextSchedule(schedule, created)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("When the checks of past runs are pruned", func() {
+
+		It("should not run the schedule again with a history limit of 0", func() {
+			schedule := &mmv1alpha1.DependencyUpdateSchedule{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              scheduleName,
+					Namespace:         MintMakerNamespaceName,
+					CreationTimestamp: metav1.NewTime(time.Now().Add(-90 * time.Minute)),
+				},
+				Spec: mmv1alpha1.DependencyUpdateScheduleSpec{
+					Schedule:                     "0 * * * *",
+					SuccessfulChecksHistoryLimit: ptr.To(int32(0)),
+				},
+			}
+			fakeClient := fake.NewClientBuilder().WithScheme(k8sClient.Scheme()).
+				WithObjects(schedule).
+				WithStatusSubresource(&mmv1alpha1.DependencyUpdateSchedule{}, &mmv1alpha1.DependencyUpdateCheck{}).
+				Build()
+			reconciler := &DependencyUpdateScheduleReconciler{Client: fakeClient, Scheme: k8sClient.Scheme(), Clock: &testClock{}}
+			reconcileAndList := func() []mmv1alpha1.DependencyUpdateCheck {
+				_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: scheduleKey})
+				Expect(err).NotTo(HaveOccurred())
+				checks := &mmv1alpha1.DependencyUpdateCheckList{}
+				Expect(fakeClient.List(ctx, checks, client.InNamespace(MintMakerNamespaceName))).To(Succeed())
+				return checks.Items
+			}
+
+			checks := reconcileAndList()
+			Expect(checks).To(HaveLen(1))
+			Expect(fakeClient.Get(ctx, scheduleKey, schedule)).To(Succeed())
+			Expect(schedule.Status.LastScheduleTime).NotTo(BeNil())
+			lastScheduleTime := schedule.Status.LastScheduleTime.Time
+
+			// The check completes and is pruned by the next reconcile
+			check := checks[0]
+			check.Status.ObservedGeneration = check.Generation
+			check.Status.Phase = mmv1alpha1.PhaseCompleted
+			Expect(fakeClient.Status().Update(ctx, &check)).To(Succeed())
+
+			Expect(reconcileAndList()).To(BeEmpty())
+			Expect(reconcileAndList()).To(BeEmpty())
+			Expect(fakeClient.Get(ctx, scheduleKey, schedule)).To(Succeed())
+			Expect(schedule.Status.LastScheduleTime.Time).To(BeTemporally("==", lastScheduleTime))
+		})
+	})
+})
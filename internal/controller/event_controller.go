@@ -32,6 +32,7 @@ import (
 	appstudiov1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
 
 	component "github.com/konflux-ci/mintmaker/internal/component"
+	. "github.com/konflux-ci/mintmaker/internal/constant"
 )
 
 // EventReconciler reconciles a Event object
@@ -193,23 +194,46 @@ func (r *EventReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 		componentName := pod.Labels[MintMakerComponentNameLabel]
 		componentNamespace := pod.Labels[MintMakerComponentNamespaceLabel]
 
-		// Get the component
-		var comp appstudiov1alpha1.Component
-		if err := r.Client.Get(ctx, client.ObjectKey{Namespace: componentNamespace, Name: componentName}, &comp); err != nil {
-			if apierrors.IsNotFound(err) {
-				// Component has gone, we can't proceed
+		var gitComp component.GitComponent
+		var err error
+		if componentName == "" {
+			// The PipelineRun scans a repository without a Component,
+			// its git URL is stored on the PipelineRun
+			var plr tektonv1.PipelineRun
+			if err := r.Client.Get(ctx, client.ObjectKey{Namespace: pod.Namespace, Name: pod.Labels["tekton.dev/pipelineRun"]}, &plr); err != nil {
+				if apierrors.IsNotFound(err) {
+					// PipelineRun has gone, we can't proceed
+					return ctrl.Result{}, nil
+				}
+				errMessage = err.Error()
+				return ctrl.Result{}, err
+			}
+
+			gitComp, err = component.NewGitComponentFromURL(ctx, plr.Annotations[MintMakerGitURLAnnotationName], nil, componentNamespace, r.Client)
+			if err != nil {
+				errMessage = err.Error()
+				// Do not requeue, the error is not related to the cluster issues
 				return ctrl.Result{}, nil
 			}
-			errMessage = err.Error()
-			return ctrl.Result{}, err
-		}
+		} else {
+			// Get the component
+			var comp appstudiov1alpha1.Component
+			if err := r.Client.Get(ctx, client.ObjectKey{Namespace: componentNamespace, Name: componentName}, &comp); err != nil {
+				if apierrors.IsNotFound(err) {
+					// Component has gone, we can't proceed
+					return ctrl.Result{}, nil
+				}
+				errMessage = err.Error()
+				return ctrl.Result{}, err
+			}
 
-		// Create GitComponent from Component
-		gitComp, err := component.NewGitComponent(ctx, &comp, r.Client)
-		if err != nil {
-			errMessage = err.Error()
-			// Do not requeue, the error is not related to the cluster issues
-			return ctrl.Result{}, nil
+			// Create GitComponent from Component
+			gitComp, err = component.NewGitComponent(ctx, &comp, r.Client)
+			if err != nil {
+				errMessage = err.Error()
+				// Do not requeue, the error is not related to the cluster issues
+				return ctrl.Result{}, nil
+			}
 		}
 
 		// When this is a GitHub component, it also refreshes token if needed
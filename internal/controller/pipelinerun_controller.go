@@ -16,17 +16,27 @@ package controller
 
 import (
 	"context"
+	"fmt"
+	"slices"
 	"strings"
 	"time"
 
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
 	"knative.dev/pkg/apis"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/event"
+	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+
+	mmv1alpha1 "github.com/konflux-ci/mintmaker/api/v1alpha1"
+	. "github.com/konflux-ci/mintmaker/internal/constant"
 )
 
 var (
@@ -35,18 +45,203 @@ var (
 	MintMakerComponentNamespaceLabel = "mintmaker.appstudio.redhat.com/namespace"
 )
 
+// retryableTaskRunReasons are reasons of failed TaskRuns caused by the
+// environment rather than by Renovate, e.g. a missing token Secret
+var retryableTaskRunReasons = []string{
+	string(tektonv1.TaskRunReasonImagePullFailed),
+	string(tektonv1.TaskRunReasonCreateContainerConfigError),
+	string(tektonv1.TaskRunReasonPodCreationFailed),
+}
+
+// retryableFailureMessages are parts of messages of failed TaskRuns whose pod
+// was evicted or lost with its node
+var retryableFailureMessages = []string{
+	"evicted",
+	"the node was low on resource",
+	"node shutdown",
+}
+
 // PipelineRunReconciler reconciles a PipelineRun object
 type PipelineRunReconciler struct {
 	Client client.Client
 	Scheme *runtime.Scheme
+	// QueueReleases is sent the DependencyUpdateChecks with queued targets
+	// when a PipelineRun finishes, see DependencyUpdateCheckReconciler
+	QueueReleases chan<- event.GenericEvent
+	// MaxAttempts is the maximum number of PipelineRuns created for a target
+	// whose PipelineRuns fail for transient reasons. 0 or 1 disables retries.
+	MaxAttempts int
+	// RetryBackoff is the delay before the first retry, it doubles with every
+	// further retry
+	RetryBackoff time.Duration
 }
 
 // +kubebuilder:rbac:groups=tekton.dev,resources=pipelineruns,verbs=get;list;watch
+// +kubebuilder:rbac:groups=tekton.dev,resources=taskruns,verbs=get;list;watch
+// +kubebuilder:rbac:groups=appstudio.redhat.com,resources=dependencyupdatechecks,verbs=get;list;watch
+// +kubebuilder:rbac:groups=appstudio.redhat.com,resources=dependencyupdatechecks/status,verbs=get;update;patch
 
+// Reconcile is called for finished PipelineRuns. The target of a PipelineRun
+// which failed for a transient reason is queued again on its check. The
+// finished PipelineRun may also make room for queued targets, so the checks
+// which have some are released to the DependencyUpdateCheck controller, the
+// oldest check first.
 func (r *PipelineRunReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := ctrllog.FromContext(ctx).WithName("PipelineRunController")
+	ctx = ctrllog.IntoContext(ctx, log)
+
+	var retriedCheck *mmv1alpha1.DependencyUpdateCheck
+	pipelineRun := &tektonv1.PipelineRun{}
+	if err := r.Client.Get(ctx, req.NamespacedName, pipelineRun); err != nil {
+		if !errors.IsNotFound(err) {
+			return ctrl.Result{}, err
+		}
+	} else if r.MaxAttempts > 1 {
+		if failure := r.getRetryableFailure(ctx, pipelineRun); failure != "" {
+			retriedCheck, err = r.retryPipelineRun(ctx, pipelineRun, failure)
+			if err != nil {
+				log.Error(err, "failed to retry PipelineRun", "pipelineRun", pipelineRun.Name)
+				return ctrl.Result{}, err
+			}
+		}
+	}
+
+	if r.QueueReleases == nil {
+		return ctrl.Result{}, nil
+	}
+
+	// The cache may not have the retried target yet
+	if retriedCheck != nil {
+		if err := r.releaseQueue(ctx, retriedCheck); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	checks := &mmv1alpha1.DependencyUpdateCheckList{}
+	if err := r.Client.List(ctx, checks, client.InNamespace(MintMakerNamespaceName)); err != nil {
+		return ctrl.Result{}, err
+	}
+	slices.SortFunc(checks.Items, func(a, b mmv1alpha1.DependencyUpdateCheck) int {
+		return a.CreationTimestamp.Compare(b.CreationTimestamp.Time)
+	})
+
+	for i := range checks.Items {
+		check := &checks.Items[i]
+		if check.Status.QueuedTargets == 0 {
+			continue
+		}
+		if err := r.releaseQueue(ctx, check); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
 	return ctrl.Result{}, nil
 }
 
+// releaseQueue triggers the scheduling of the queued targets of the check
+func (r *PipelineRunReconciler) releaseQueue(ctx context.Context, check *mmv1alpha1.DependencyUpdateCheck) error {
+	select {
+	case r.QueueReleases <- event.GenericEvent{Object: check}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// getRetryableFailure returns the failure message of the TaskRun which made
+// the PipelineRun fail for a transient reason, or an empty string if the
+// PipelineRun didn't fail for one. Cancelled and timed out PipelineRuns are
+// never retried.
+func (r *PipelineRunReconciler) getRetryableFailure(ctx context.Context, pipelineRun *tektonv1.PipelineRun) string {
+	log := ctrllog.FromContext(ctx)
+
+	condition := pipelineRun.Status.GetCondition(apis.ConditionSucceeded)
+	if condition == nil || !condition.IsFalse() || condition.Reason != string(tektonv1.PipelineRunReasonFailed) {
+		return ""
+	}
+
+	for _, child := range pipelineRun.Status.ChildReferences {
+		if child.Kind != "TaskRun" {
+			continue
+		}
+		taskRun := &tektonv1.TaskRun{}
+		if err := r.Client.Get(ctx, types.NamespacedName{Namespace: pipelineRun.Namespace, Name: child.Name}, taskRun); err != nil {
+			log.Info("failed to get TaskRun of failed PipelineRun", "pipelineRun", pipelineRun.Name, "taskRun", child.Name, "err", err)
+			continue
+		}
+		taskRunCondition := taskRun.Status.GetCondition(apis.ConditionSucceeded)
+		if taskRunCondition == nil || !taskRunCondition.IsFalse() {
+			continue
+		}
+		if slices.Contains(retryableTaskRunReasons, taskRunCondition.Reason) {
+			return taskRunCondition.Message
+		}
+		message := strings.ToLower(taskRunCondition.Message)
+		for _, retryableMessage := range retryableFailureMessages {
+			if strings.Contains(message, retryableMessage) {
+				return taskRunCondition.Message
+			}
+		}
+	}
+	return ""
+}
+
+// retryPipelineRun queues the target of the failed PipelineRun on its check
+// again, with exponential backoff. Once the target runs out of attempts, it's
+// marked as failed. It returns the check, or nil if the target isn't part of
+// the current run of the check any more.
+func (r *PipelineRunReconciler) retryPipelineRun(ctx context.Context, pipelineRun *tektonv1.PipelineRun, failure string) (*mmv1alpha1.DependencyUpdateCheck, error) {
+	log := ctrllog.FromContext(ctx)
+
+	checkName := pipelineRun.Labels[MintMakerCheckLabelName]
+	if checkName == "" {
+		return nil, nil
+	}
+	var retriedCheck *mmv1alpha1.DependencyUpdateCheck
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		retriedCheck = nil
+		check := &mmv1alpha1.DependencyUpdateCheck{}
+		if err := r.Client.Get(ctx, types.NamespacedName{Namespace: pipelineRun.Namespace, Name: checkName}, check); err != nil {
+			return client.IgnoreNotFound(err)
+		}
+		if string(check.UID) != pipelineRun.Labels[MintMakerCheckUIDLabelName] {
+			return nil
+		}
+
+		i := slices.IndexFunc(check.Status.Targets, func(target mmv1alpha1.TargetStatus) bool {
+			return target.State == mmv1alpha1.TargetStateScheduled && target.PipelineRun == pipelineRun.Name
+		})
+		if i < 0 {
+			return nil
+		}
+		target := &check.Status.Targets[i]
+
+		if int(target.Attempts) >= r.MaxAttempts {
+			log.Info("PipelineRun failed for a transient reason, no attempts left", "pipelineRun", pipelineRun.Name, "attempts", target.Attempts)
+			*target = failed(*target, mmv1alpha1.TargetReasonRetriesExhausted,
+				fmt.Errorf("PipelineRun %s failed after %d attempts: %s", pipelineRun.Name, target.Attempts, failure))
+		} else {
+			backoff := r.RetryBackoff << max(target.Attempts-1, 0)
+			notBefore := metav1.NewTime(time.Now().Add(backoff))
+			log.Info("PipelineRun failed for a transient reason, retrying", "pipelineRun", pipelineRun.Name,
+				"attempts", target.Attempts, "notBefore", notBefore)
+			target.State = mmv1alpha1.TargetStateQueued
+			target.NotBefore = &notBefore
+			target.Reason = mmv1alpha1.TargetReasonRetried
+			target.Message = fmt.Sprintf("PipelineRun %s failed: %s", pipelineRun.Name, failure)
+			check.Status.Phase = mmv1alpha1.PhaseRunning
+			check.Status.CompletionTime = nil
+		}
+		settleRun(&check.Status)
+
+		if err := r.Client.Status().Update(ctx, check); err != nil {
+			return err
+		}
+		retriedCheck = check
+		return nil
+	})
+	return retriedCheck, err
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *PipelineRunReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	// We only react to Update events for PipelineRun in mintmaker namespace.
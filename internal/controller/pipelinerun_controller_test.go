@@ -16,13 +16,25 @@ package controller
 
 import (
 	"bytes"
+	"context"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/pkg/apis"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 
+	mmv1alpha1 "github.com/konflux-ci/mintmaker/api/v1alpha1"
 	. "github.com/konflux-ci/mintmaker/internal/constant"
 	tekton "github.com/konflux-ci/mintmaker/internal/tekton"
 )
@@ -129,4 +141,201 @@ var _ = Describe("PipelineRun Controller", func() {
 			}, timeout, interval).Should(Succeed())
 		})
 	})
+
+	Context("When a pipelinerun finishes while targets are queued", func() {
+
+		It("should release the dependencyupdatechecks with queued targets, the oldest first", func() {
+			newCheck := func(name string, created time.Time, queued int32) *mmv1alpha1.DependencyUpdateCheck {
+				return &mmv1alpha1.DependencyUpdateCheck{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:              name,
+						Namespace:         MintMakerNamespaceName,
+						CreationTimestamp: metav1.NewTime(created),
+					},
+					Status: mmv1alpha1.DependencyUpdateCheckStatus{QueuedTargets: queued},
+				}
+			}
+			now := time.Now()
+			queueReleases := make(chan event.GenericEvent, 10)
+			reconciler := &PipelineRunReconciler{
+				Client: fake.NewClientBuilder().WithScheme(k8sClient.Scheme()).WithObjects(
+					newCheck("newer", now, 1),
+					newCheck("completed", now.Add(-time.Hour), 0),
+					newCheck("older", now.Add(-2*time.Hour), 3),
+				).Build(),
+				QueueReleases: queueReleases,
+			}
+
+			_, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: "test-plr", Namespace: MintMakerNamespaceName}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(queueReleases).To(HaveLen(2))
+			Expect((<-queueReleases).Object.GetName()).To(Equal("older"))
+			Expect((<-queueReleases).Object.GetName()).To(Equal("newer"))
+		})
+	})
+
+	Context("When a pipelinerun fails", func() {
+
+		const (
+			checkName = "retried-check"
+			checkUID  = "retried-check-uid"
+			plrName   = "failed-plr"
+		)
+
+		var (
+			queueReleases chan event.GenericEvent
+			check         *mmv1alpha1.DependencyUpdateCheck
+		)
+
+		// newFailure returns a pipelinerun which failed for the given reason, with
+		// a taskrun which failed with the given reason and message
+		newFailure := func(reason string, taskRunReason string, taskRunMessage string) (*tektonv1.PipelineRun, *tektonv1.TaskRun) {
+			plr, err := tekton.NewPipelineRunBuilder(plrName, MintMakerNamespaceName).
+				WithLabels(map[string]string{
+					MintMakerCheckLabelName:    checkName,
+					MintMakerCheckUIDLabelName: checkUID,
+				}).Build()
+			Expect(err).NotTo(HaveOccurred())
+			plr.Status.MarkFailed(reason, "%s", "pipelinerun failed")
+			plr.Status.ChildReferences = []tektonv1.ChildStatusReference{
+				{TypeMeta: runtime.TypeMeta{Kind: "TaskRun"}, Name: plrName + "-build"},
+			}
+			taskRun := &tektonv1.TaskRun{ObjectMeta: metav1.ObjectMeta{Name: plrName + "-build", Namespace: MintMakerNamespaceName}}
+			taskRun.Status.SetCondition(&apis.Condition{
+				Type:    apis.ConditionSucceeded,
+				Status:  corev1.ConditionFalse,
+				Reason:  taskRunReason,
+				Message: taskRunMessage,
+			})
+			return plr, taskRun
+		}
+
+		// reconcileFailure runs the reconciler for a pipelinerun which failed for the
+		// given reason, with a taskrun which failed with the given reason and message
+		reconcileFailure := func(reason string, taskRunReason string, taskRunMessage string) *mmv1alpha1.DependencyUpdateCheck {
+			plr, taskRun := newFailure(reason, taskRunReason, taskRunMessage)
+			fakeClient := fake.NewClientBuilder().WithScheme(k8sClient.Scheme()).
+				WithObjects(check, plr, taskRun).
+				WithStatusSubresource(&mmv1alpha1.DependencyUpdateCheck{}).
+				Build()
+			reconciler := &PipelineRunReconciler{
+				Client:        fakeClient,
+				QueueReleases: queueReleases,
+				MaxAttempts:   3,
+				RetryBackoff:  time.Minute,
+			}
+			_, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: plrName, Namespace: MintMakerNamespaceName}})
+			Expect(err).NotTo(HaveOccurred())
+
+			updatedCheck := &mmv1alpha1.DependencyUpdateCheck{}
+			Expect(fakeClient.Get(ctx, types.NamespacedName{Name: checkName, Namespace: MintMakerNamespaceName}, updatedCheck)).To(Succeed())
+			return updatedCheck
+		}
+
+		BeforeEach(func() {
+			queueReleases = make(chan event.GenericEvent, 10)
+			check = &mmv1alpha1.DependencyUpdateCheck{
+				ObjectMeta: metav1.ObjectMeta{Name: checkName, Namespace: MintMakerNamespaceName, UID: checkUID},
+				Status: mmv1alpha1.DependencyUpdateCheckStatus{
+					Phase: mmv1alpha1.PhaseCompleted,
+					Targets: []mmv1alpha1.TargetStatus{{
+						Host:        "github.com",
+						Repository:  "org/repo",
+						Branch:      "main",
+						State:       mmv1alpha1.TargetStateScheduled,
+						PipelineRun: plrName,
+						Attempts:    1,
+					}},
+					TotalTargets:     1,
+					ScheduledTargets: 1,
+				},
+			}
+		})
+
+		It("should queue the target again when the image pull failed", func() {
+			updatedCheck := reconcileFailure(string(tektonv1.PipelineRunReasonFailed), string(tektonv1.TaskRunReasonImagePullFailed), "image pull failed")
+			target := updatedCheck.Status.Targets[0]
+			Expect(target.State).To(Equal(mmv1alpha1.TargetStateQueued))
+			Expect(target.Reason).To(Equal(mmv1alpha1.TargetReasonRetried))
+			Expect(target.NotBefore.Time).To(BeTemporally("~", time.Now().Add(time.Minute), 5*time.Second))
+			Expect(updatedCheck.Status.Phase).To(Equal(mmv1alpha1.PhaseRunning))
+			Expect(updatedCheck.Status.QueuedTargets).To(BeEquivalentTo(1))
+			Expect(queueReleases).To(Receive(HaveField("Object.GetName()", checkName)))
+		})
+
+		It("should back off exponentially when the pod was evicted", func() {
+			check.Status.Targets[0].Attempts = 2
+			updatedCheck := reconcileFailure(string(tektonv1.PipelineRunReasonFailed), string(tektonv1.TaskRunReasonFailed),
+				"The node was low on resource: memory.")
+			target := updatedCheck.Status.Targets[0]
+			Expect(target.State).To(Equal(mmv1alpha1.TargetStateQueued))
+			Expect(target.NotBefore.Time).To(BeTemporally("~", time.Now().Add(2*time.Minute), 5*time.Second))
+		})
+
+		It("should fail the target when it runs out of attempts", func() {
+			check.Status.Targets[0].Attempts = 3
+			updatedCheck := reconcileFailure(string(tektonv1.PipelineRunReasonFailed), string(tektonv1.TaskRunReasonCreateContainerConfigError), "secret key not found")
+			target := updatedCheck.Status.Targets[0]
+			Expect(target.State).To(Equal(mmv1alpha1.TargetStateFailed))
+			Expect(target.Reason).To(Equal(mmv1alpha1.TargetReasonRetriesExhausted))
+			Expect(updatedCheck.Status.Phase).To(Equal(mmv1alpha1.PhaseFailed))
+		})
+
+		It("should not retry a failure of Renovate", func() {
+			updatedCheck := reconcileFailure(string(tektonv1.PipelineRunReasonFailed), string(tektonv1.TaskRunReasonFailed),
+				`"step-renovate" exited with code 1`)
+			Expect(updatedCheck.Status.Targets[0].State).To(Equal(mmv1alpha1.TargetStateScheduled))
+			Expect(queueReleases).To(BeEmpty())
+		})
+
+		It("should not retry a cancelled pipelinerun", func() {
+			updatedCheck := reconcileFailure(string(tektonv1.PipelineRunReasonCancelled), string(tektonv1.TaskRunReasonImagePullFailed), "image pull failed")
+			Expect(updatedCheck.Status.Targets[0].State).To(Equal(mmv1alpha1.TargetStateScheduled))
+		})
+
+		It("should keep the retried target when the check is reconciled meanwhile", func() {
+			check.Generation = 1
+			check.Status.ObservedGeneration = 1
+			check.Status.Phase = mmv1alpha1.PhaseRunning
+			plr, taskRun := newFailure(string(tektonv1.PipelineRunReasonFailed), string(tektonv1.TaskRunReasonImagePullFailed), "image pull failed")
+			fakeClient := fake.NewClientBuilder().WithScheme(k8sClient.Scheme()).
+				WithObjects(check, plr, taskRun).
+				WithStatusSubresource(&mmv1alpha1.DependencyUpdateCheck{}).
+				Build()
+			pipelineRunReconciler := &PipelineRunReconciler{Client: fakeClient, MaxAttempts: 3, RetryBackoff: time.Minute}
+
+			// The pipelinerun is retried right after the check reconciler read the check
+			retried := false
+			checkReconciler := &DependencyUpdateCheckReconciler{
+				Client: interceptor.NewClient(fakeClient, interceptor.Funcs{
+					Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+						if err := c.Get(ctx, key, obj, opts...); err != nil {
+							return err
+						}
+						if _, ok := obj.(*mmv1alpha1.DependencyUpdateCheck); ok && !retried {
+							retried = true
+							_, err := pipelineRunReconciler.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: plrName, Namespace: MintMakerNamespaceName}})
+							return err
+						}
+						return nil
+					},
+				}),
+				Scheme: k8sClient.Scheme(),
+			}
+			checkRequest := ctrl.Request{NamespacedName: types.NamespacedName{Name: checkName, Namespace: MintMakerNamespaceName}}
+
+			_, err := checkReconciler.Reconcile(ctx, checkRequest)
+			Expect(apierrors.IsConflict(err)).To(BeTrue())
+			_, err = checkReconciler.Reconcile(ctx, checkRequest)
+			Expect(err).NotTo(HaveOccurred())
+
+			updatedCheck := &mmv1alpha1.DependencyUpdateCheck{}
+			Expect(fakeClient.Get(ctx, checkRequest.NamespacedName, updatedCheck)).To(Succeed())
+			target := updatedCheck.Status.Targets[0]
+			Expect(target.State).To(Equal(mmv1alpha1.TargetStateQueued))
+			Expect(target.Reason).To(Equal(mmv1alpha1.TargetReasonRetried))
+			Expect(updatedCheck.Status.Phase).To(Equal(mmv1alpha1.PhaseRunning))
+			Expect(updatedCheck.Status.QueuedTargets).To(BeEquivalentTo(1))
+		})
+	})
 })
@@ -127,6 +127,11 @@ var _ = BeforeSuite(func() {
 						MintMakerNamespaceName: {},
 					},
 				},
+				&mmv1alpha1.DependencyUpdateSchedule{}: {
+					Namespaces: map[string]cache.Config{
+						MintMakerNamespaceName: {},
+					},
+				},
 				&corev1.Event{}: {
 					Namespaces: map[string]cache.Config{
 						MintMakerNamespaceName: {},
@@ -137,6 +142,11 @@ var _ = BeforeSuite(func() {
 						MintMakerNamespaceName: {},
 					},
 				},
+				&tektonv1.TaskRun{}: {
+					Namespaces: map[string]cache.Config{
+						MintMakerNamespaceName: {},
+					},
+				},
 			},
 		},
 	})
@@ -145,6 +155,9 @@ var _ = BeforeSuite(func() {
 	err = (NewDependencyUpdateCheckReconciler(k8sManager.GetClient(), k8sManager.GetScheme(), k8sManager.GetEventRecorderFor("DependencyUpdateCheckController"))).SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 
+	err = (&DependencyUpdateScheduleReconciler{Client: k8sManager.GetClient(), Scheme: k8sManager.GetScheme(), Clock: scheduleClock}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
 	err = (&PipelineRunReconciler{Client: k8sManager.GetClient(), Scheme: k8sManager.GetScheme()}).SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 
@@ -83,6 +83,38 @@ func createNamespace(name string) {
 	}, timeout, interval).Should(BeTrue())
 }
 
+func labelNamespace(name string, labels map[string]string) {
+	namespace := &corev1.Namespace{}
+	Expect(k8sClient.Get(ctx, client.ObjectKey{Name: name}, namespace)).Should(Succeed())
+
+	if namespace.Labels == nil {
+		namespace.Labels = make(map[string]string)
+	}
+	for key, value := range labels {
+		namespace.Labels[key] = value
+	}
+
+	Expect(k8sClient.Update(ctx, namespace)).Should(Succeed())
+}
+
+func annotateNamespace(name string, annotations map[string]string) {
+	namespace := &corev1.Namespace{}
+	Expect(k8sClient.Get(ctx, client.ObjectKey{Name: name}, namespace)).Should(Succeed())
+
+	if namespace.Annotations == nil {
+		namespace.Annotations = make(map[string]string)
+	}
+	for key, value := range annotations {
+		if value == "" {
+			delete(namespace.Annotations, key)
+			continue
+		}
+		namespace.Annotations[key] = value
+	}
+
+	Expect(k8sClient.Update(ctx, namespace)).Should(Succeed())
+}
+
 func createServiceAccount(resourceKey types.NamespacedName) {
 	sa := &corev1.ServiceAccount{
 		ObjectMeta: metav1.ObjectMeta{
@@ -363,6 +395,67 @@ func deleteComponent(resourceKey types.NamespacedName) {
 	}, timeout, interval).Should(BeTrue())
 }
 
+func labelComponent(resourceKey types.NamespacedName, labels map[string]string) {
+	component := &appstudiov1alpha1.Component{}
+	Expect(k8sClient.Get(ctx, resourceKey, component)).Should(Succeed())
+
+	if component.Labels == nil {
+		component.Labels = make(map[string]string)
+	}
+	for key, value := range labels {
+		component.Labels[key] = value
+	}
+
+	Expect(k8sClient.Update(ctx, component)).Should(Succeed())
+
+	getComponent(resourceKey)
+}
+
+func createApplication(resourceKey types.NamespacedName, labels map[string]string) {
+	application := &appstudiov1alpha1.Application{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      resourceKey.Name,
+			Namespace: resourceKey.Namespace,
+			Labels:    labels,
+		},
+		Spec: appstudiov1alpha1.ApplicationSpec{
+			DisplayName: resourceKey.Name,
+		},
+	}
+
+	Expect(k8sClient.Create(ctx, application)).Should(Succeed())
+}
+
+func deleteApplication(resourceKey types.NamespacedName) {
+	application := &appstudiov1alpha1.Application{}
+	if err := k8sClient.Get(ctx, resourceKey, application); err != nil {
+		if k8sErrors.IsNotFound(err) {
+			return
+		}
+		Fail(err.Error())
+	}
+	Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, application))).Should(Succeed())
+	Eventually(func() bool {
+		return k8sErrors.IsNotFound(k8sClient.Get(ctx, resourceKey, application))
+	}, timeout, interval).Should(BeTrue())
+}
+
+func annotateComponent(resourceKey types.NamespacedName, annotations map[string]string) {
+	component := &appstudiov1alpha1.Component{}
+	Expect(k8sClient.Get(ctx, resourceKey, component)).Should(Succeed())
+
+	if component.Annotations == nil {
+		component.Annotations = make(map[string]string)
+	}
+	for key, value := range annotations {
+		component.Annotations[key] = value
+	}
+
+	Expect(k8sClient.Update(ctx, component)).Should(Succeed())
+
+	getComponent(resourceKey)
+}
+
 func disableComponentMintmaker(resourceKey types.NamespacedName) {
 	component := &appstudiov1alpha1.Component{}
 	Expect(k8sClient.Get(ctx, resourceKey, component)).Should(Succeed())
@@ -404,6 +497,19 @@ func createDependencyUpdateCheck(resourceKey types.NamespacedName, processed boo
 	getDependencyUpdateCheck(resourceKey)
 }
 
+func createDependencyUpdateCheckWithSpec(resourceKey types.NamespacedName, spec mmv1alpha1.DependencyUpdateCheckSpec) {
+	dependencyUpdateCheck := &mmv1alpha1.DependencyUpdateCheck{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      resourceKey.Name,
+			Namespace: resourceKey.Namespace,
+		},
+		Spec: spec,
+	}
+
+	Expect(k8sClient.Create(ctx, dependencyUpdateCheck)).Should(Succeed())
+	getDependencyUpdateCheck(resourceKey)
+}
+
 func getDependencyUpdateCheck(resourceKey types.NamespacedName) *mmv1alpha1.DependencyUpdateCheck {
 	dependencyUpdateCheck := &mmv1alpha1.DependencyUpdateCheck{}
 	Eventually(func() bool {
@@ -434,6 +540,64 @@ func deleteDependencyUpdateCheck(resourceKey types.NamespacedName) {
 	}, timeout, interval).Should(BeTrue())
 }
 
+func createDependencyUpdateSchedule(resourceKey types.NamespacedName, schedule string, suspend bool, checkSpec mmv1alpha1.DependencyUpdateCheckSpec) {
+	dependencyUpdateSchedule := &mmv1alpha1.DependencyUpdateSchedule{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      resourceKey.Name,
+			Namespace: resourceKey.Namespace,
+		},
+		Spec: mmv1alpha1.DependencyUpdateScheduleSpec{
+			Schedule: schedule,
+			Suspend:  &suspend,
+			CheckTemplate: mmv1alpha1.DependencyUpdateCheckTemplateSpec{
+				Spec: checkSpec,
+			},
+		},
+	}
+
+	Expect(k8sClient.Create(ctx, dependencyUpdateSchedule)).Should(Succeed())
+	getDependencyUpdateSchedule(resourceKey)
+}
+
+func getDependencyUpdateSchedule(resourceKey types.NamespacedName) *mmv1alpha1.DependencyUpdateSchedule {
+	dependencyUpdateSchedule := &mmv1alpha1.DependencyUpdateSchedule{}
+	Eventually(func() bool {
+		if err := k8sClient.Get(ctx, resourceKey, dependencyUpdateSchedule); err != nil {
+			return false
+		}
+		return true
+	}, timeout, interval).Should(BeTrue())
+	return dependencyUpdateSchedule
+}
+
+func deleteDependencyUpdateSchedule(resourceKey types.NamespacedName) {
+	dependencyUpdateSchedule := &mmv1alpha1.DependencyUpdateSchedule{}
+	if err := k8sClient.Get(ctx, resourceKey, dependencyUpdateSchedule); err != nil {
+		if k8sErrors.IsNotFound(err) {
+			return
+		}
+		Fail(err.Error())
+	}
+	if err := k8sClient.Delete(ctx, dependencyUpdateSchedule); err != nil {
+		if !k8sErrors.IsNotFound(err) {
+			Fail(err.Error())
+		}
+		return
+	}
+	Eventually(func() bool {
+		return k8sErrors.IsNotFound(k8sClient.Get(ctx, resourceKey, dependencyUpdateSchedule))
+	}, timeout, interval).Should(BeTrue())
+}
+
+// listScheduledChecks returns the DependencyUpdateChecks created by the named schedule
+func listScheduledChecks(scheduleName string) []mmv1alpha1.DependencyUpdateCheck {
+	checks := &mmv1alpha1.DependencyUpdateCheckList{}
+
+	err := k8sClient.List(ctx, checks, client.InNamespace(MintMakerNamespaceName), client.MatchingLabels{MintMakerScheduleLabelName: scheduleName})
+	Expect(err).ToNot(HaveOccurred())
+	return checks.Items
+}
+
 func listPipelineRuns(namespace string) []tektonv1.PipelineRun {
 	pipelineruns := &tektonv1.PipelineRunList{}
 
@@ -450,6 +614,15 @@ func deletePipelineRuns(namespace string) {
 	}, 10*time.Second, 100*time.Millisecond).Should(BeTrue())
 }
 
+// finishPipelineRuns marks the PipelineRuns of the namespace as succeeded,
+// there is no Tekton controller which would run them
+func finishPipelineRuns(namespace string) {
+	for _, pipelineRun := range listPipelineRuns(namespace) {
+		pipelineRun.Status.MarkSucceeded(string(tektonv1.PipelineRunReasonSuccessful), "%s")
+		Expect(k8sClient.Status().Update(ctx, &pipelineRun)).Should(Succeed())
+	}
+}
+
 func deletePipelineRun(resourceKey types.NamespacedName) {
 	pipelineRun := &tektonv1.PipelineRun{}
 	if err := k8sClient.Get(ctx, resourceKey, pipelineRun); err != nil {
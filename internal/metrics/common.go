@@ -28,6 +28,14 @@ var (
 		},
 		[]string{"namespace", "name"},
 	)
+	dependencyUpdateCheckQueuedTargets = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: "mintmaker",
+			Name:      "dependency_update_check_queued_targets",
+			Help:      "Number of targets of a DependencyUpdateCheck waiting for running PipelineRuns to finish",
+		},
+		[]string{"namespace", "name"},
+	)
 )
 
 func RegisterCommonMetrics(ctx context.Context, registerer prometheus.Registerer) error {
@@ -38,6 +46,9 @@ func RegisterCommonMetrics(ctx context.Context, registerer prometheus.Registerer
 	if err := registerer.Register(dependencyUpdateCheckCreationTime); err != nil {
 		return fmt.Errorf("failed to register metrics: %w", err)
 	}
+	if err := registerer.Register(dependencyUpdateCheckQueuedTargets); err != nil {
+		return fmt.Errorf("failed to register metrics: %w", err)
+	}
 
 	ticker := time.NewTicker(10 * time.Minute)
 	log.Info("Starting metrics")
@@ -93,6 +104,16 @@ func RecordDependencyUpdateCheckCreation(namespace, name string) {
 	dependencyUpdateCheckCreationTime.WithLabelValues(namespace, name).Set(now)
 }
 
+// RecordDependencyUpdateCheckQueuedTargets records the number of queued targets of a DependencyUpdateCheck
+func RecordDependencyUpdateCheckQueuedTargets(namespace, name string, queued int32) {
+	dependencyUpdateCheckQueuedTargets.WithLabelValues(namespace, name).Set(float64(queued))
+}
+
+// DeleteDependencyUpdateCheckQueuedTargets removes the queued targets series of a deleted DependencyUpdateCheck
+func DeleteDependencyUpdateCheckQueuedTargets(namespace, name string) {
+	dependencyUpdateCheckQueuedTargets.DeleteLabelValues(namespace, name)
+}
+
 type AvailabilityProbe interface {
 	CheckEvents(ctx context.Context) float64
 	AddEvent()
@@ -0,0 +1,181 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package selection looks up the components selected by DependencyUpdateChecks,
+// shared by the controller and the validating webhook.
+package selection
+
+import (
+	"context"
+
+	appstudiov1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
+	mmv1alpha1 "github.com/konflux-ci/mintmaker/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// GetCheckComponents returns the components selected by the spec of a DependencyUpdateCheck.
+// Components from the listed namespaces and from the namespaces matching the namespace
+// selector are combined, the component and application selectors then narrow them down.
+func GetCheckComponents(ctx context.Context, spec *mmv1alpha1.DependencyUpdateCheckSpec, apiClient client.Client) ([]appstudiov1alpha1.Component, error) {
+	components := []appstudiov1alpha1.Component{}
+
+	// A check listing only repositories doesn't scan any components
+	if len(spec.Repositories) > 0 && len(spec.Namespaces) == 0 && spec.NamespaceSelector == nil &&
+		spec.ComponentSelector == nil && spec.ApplicationSelector == nil {
+		return components, nil
+	}
+
+	if len(spec.Namespaces) == 0 && spec.NamespaceSelector == nil {
+		allComponents := &appstudiov1alpha1.ComponentList{}
+		if err := apiClient.List(ctx, allComponents, &client.ListOptions{}); err != nil {
+			return nil, err
+		}
+		components = allComponents.Items
+	}
+
+	if len(spec.Namespaces) > 0 {
+		filteredComponents, err := getFilteredComponents(ctx, spec.Namespaces, apiClient)
+		if err != nil {
+			return nil, err
+		}
+		components = append(components, filteredComponents...)
+	}
+
+	if spec.NamespaceSelector != nil {
+		selectedComponents, err := getNamespaceSelectedComponents(ctx, spec.NamespaceSelector, apiClient)
+		if err != nil {
+			return nil, err
+		}
+		// A component can be both listed and selected, add it only once
+		seen := map[string]bool{}
+		for _, component := range components {
+			seen[component.Namespace+"/"+component.Name] = true
+		}
+		for _, component := range selectedComponents {
+			if !seen[component.Namespace+"/"+component.Name] {
+				components = append(components, component)
+			}
+		}
+	}
+
+	if spec.ComponentSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(spec.ComponentSelector)
+		if err != nil {
+			return nil, err
+		}
+		matchingComponents := []appstudiov1alpha1.Component{}
+		for _, component := range components {
+			if selector.Matches(labels.Set(component.Labels)) {
+				matchingComponents = append(matchingComponents, component)
+			}
+		}
+		components = matchingComponents
+	}
+
+	if spec.ApplicationSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(spec.ApplicationSelector)
+		if err != nil {
+			return nil, err
+		}
+		applicationList := &appstudiov1alpha1.ApplicationList{}
+		if err := apiClient.List(ctx, applicationList, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+			return nil, err
+		}
+		selectedApplications := map[string]bool{}
+		for _, application := range applicationList.Items {
+			selectedApplications[application.Namespace+"/"+application.Name] = true
+		}
+		matchingComponents := []appstudiov1alpha1.Component{}
+		for _, component := range components {
+			if selectedApplications[component.Namespace+"/"+component.Spec.Application] {
+				matchingComponents = append(matchingComponents, component)
+			}
+		}
+		components = matchingComponents
+	}
+
+	return components, nil
+}
+
+// Get all components from namespaces whose labels match the selector
+func getNamespaceSelectedComponents(ctx context.Context, namespaceSelector *metav1.LabelSelector, apiClient client.Client) ([]appstudiov1alpha1.Component, error) {
+	selector, err := metav1.LabelSelectorAsSelector(namespaceSelector)
+	if err != nil {
+		return nil, err
+	}
+	namespaceList := &corev1.NamespaceList{}
+	if err := apiClient.List(ctx, namespaceList, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, err
+	}
+
+	components := []appstudiov1alpha1.Component{}
+	for _, namespace := range namespaceList.Items {
+		namespaceComponentList := &appstudiov1alpha1.ComponentList{}
+		if err := apiClient.List(ctx, namespaceComponentList, client.InNamespace(namespace.Name)); err != nil {
+			return nil, err
+		}
+		components = append(components, namespaceComponentList.Items...)
+	}
+	return components, nil
+}
+
+// Get only components that match a given namespace/application/componentname
+func getFilteredComponents(ctx context.Context, namespaces []mmv1alpha1.NamespaceSpec, apiClient client.Client) ([]appstudiov1alpha1.Component, error) {
+	components := []appstudiov1alpha1.Component{}
+	err := error(nil)
+
+	// Iterate namespaces and create query filtered by namespace
+	for _, namespace := range namespaces {
+		namespaceComponentList := &appstudiov1alpha1.ComponentList{}
+		listOps := &client.ListOptions{
+			Namespace: namespace.Namespace,
+		}
+		if err := apiClient.List(ctx, namespaceComponentList, listOps); err != nil {
+			return nil, err
+		}
+		// No applications specified -> add all Namespace components, start processing next namespace
+		if len(namespace.Applications) == 0 {
+			components = append(components, namespaceComponentList.Items...)
+			continue
+		}
+		// Applications specified -> iterate and filter by application
+		for _, application := range namespace.Applications {
+			appMatchingComponents := []appstudiov1alpha1.Component{}
+			for _, component := range namespaceComponentList.Items {
+				if application.Application == component.Spec.Application {
+					appMatchingComponents = append(appMatchingComponents, component)
+				}
+			}
+			// No components specified for an application -> add all application components, start processing next application
+			if len(application.Components) == 0 {
+				components = append(components, appMatchingComponents...)
+				continue
+			}
+			// Components specified -> add components with matching names
+			for _, filterComponent := range application.Components {
+				for _, component := range appMatchingComponents {
+					if filterComponent == mmv1alpha1.Component(component.Name) {
+						components = append(components, component)
+						break
+					}
+				}
+			}
+		}
+	}
+
+	return components, err
+}
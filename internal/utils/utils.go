@@ -18,15 +18,24 @@ import (
 	"fmt"
 	"net/url"
 	"strings"
+
+	"github.com/konflux-ci/mintmaker/internal/config"
 )
 
+// GetGitPlatform returns the platform of the git repository: the platform
+// configured for its host, or else the first platform which is part of the
+// hostname, e.g. gitlab for gitlab.example.com.
 func GetGitPlatform(giturl string) (string, error) {
-	allowedGitPlatforms := []string{"github", "gitlab"}
+	allowedGitPlatforms := []string{"github", "gitlab", "bitbucket"}
 	host, err := GetGitHost(giturl)
 	if err != nil {
 		return "", err
 	}
 
+	if platform, ok := config.Get().PlatformForHost(host); ok {
+		return platform.Type, nil
+	}
+
 	var gitPlatform string
 	for _, platform := range allowedGitPlatforms {
 		if strings.Contains(host, platform) {
@@ -0,0 +1,241 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"context"
+	"path"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	mmv1alpha1 "github.com/konflux-ci/mintmaker/api/v1alpha1"
+	. "github.com/konflux-ci/mintmaker/internal/constant"
+	"github.com/konflux-ci/mintmaker/internal/selection"
+)
+
+var dependencyupdatechecklog = logf.Log.WithName("dependencyupdatecheck-resource")
+
+// SetupDependencyUpdateCheckWebhookWithManager registers the webhook for DependencyUpdateCheck in the manager.
+func SetupDependencyUpdateCheckWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr, &mmv1alpha1.DependencyUpdateCheck{}).
+		WithValidator(&DependencyUpdateCheckCustomValidator{Client: mgr.GetClient()}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-appstudio-redhat-com-v1alpha1-dependencyupdatecheck,mutating=false,failurePolicy=fail,sideEffects=None,groups=appstudio.redhat.com,resources=dependencyupdatechecks,verbs=create;update,versions=v1alpha1,name=vdependencyupdatecheck-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// DependencyUpdateCheckCustomValidator rejects DependencyUpdateChecks which would
+// be ignored or would silently scan nothing.
+type DependencyUpdateCheckCustomValidator struct {
+	Client client.Client
+}
+
+var _ admission.Validator[*mmv1alpha1.DependencyUpdateCheck] = &DependencyUpdateCheckCustomValidator{}
+
+// ValidateCreate implements admission.Validator so a webhook will be registered for the type DependencyUpdateCheck.
+func (v *DependencyUpdateCheckCustomValidator) ValidateCreate(ctx context.Context, check *mmv1alpha1.DependencyUpdateCheck) (admission.Warnings, error) {
+	dependencyupdatechecklog.Info("validation for DependencyUpdateCheck upon creation", "name", check.GetName())
+
+	return nil, v.validate(ctx, check)
+}
+
+// ValidateUpdate implements admission.Validator so a webhook will be registered for the type DependencyUpdateCheck.
+func (v *DependencyUpdateCheckCustomValidator) ValidateUpdate(ctx context.Context, oldCheck, newCheck *mmv1alpha1.DependencyUpdateCheck) (admission.Warnings, error) {
+	// Changes of metadata, e.g. removing a finalizer, must not be blocked
+	// because the cluster changed since the check was created
+	if equality.Semantic.DeepEqual(oldCheck.Spec, newCheck.Spec) {
+		return nil, nil
+	}
+	dependencyupdatechecklog.Info("validation for DependencyUpdateCheck upon update", "name", newCheck.GetName())
+
+	return nil, v.validate(ctx, newCheck)
+}
+
+// ValidateDelete implements admission.Validator so a webhook will be registered for the type DependencyUpdateCheck.
+func (v *DependencyUpdateCheckCustomValidator) ValidateDelete(ctx context.Context, check *mmv1alpha1.DependencyUpdateCheck) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *DependencyUpdateCheckCustomValidator) validate(ctx context.Context, check *mmv1alpha1.DependencyUpdateCheck) error {
+	var allErrs field.ErrorList
+
+	if check.Namespace != MintMakerNamespaceName {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("metadata", "namespace"),
+			"DependencyUpdateChecks are only processed in the "+MintMakerNamespaceName+" namespace"))
+	}
+
+	specErrs, err := v.validateSpec(ctx, &check.Spec)
+	if err != nil {
+		return apierrors.NewInternalError(err)
+	}
+	allErrs = append(allErrs, specErrs...)
+
+	// Only look for the selected components if the spec is otherwise valid,
+	// the lookup would fail or be meaningless. Checks created by a schedule
+	// aren't rejected, the schedule would retry creating them forever.
+	if len(allErrs) == 0 && !isScheduled(check) {
+		selectorErrs, err := v.validateSelectorsMatch(ctx, &check.Spec)
+		if err != nil {
+			return apierrors.NewInternalError(err)
+		}
+		allErrs = append(allErrs, selectorErrs...)
+	}
+
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(mmv1alpha1.GroupVersion.WithKind("DependencyUpdateCheck").GroupKind(), check.Name, allErrs)
+}
+
+// validateSpec returns the validation errors of the spec. The returned error
+// is set if the validation couldn't be done, e.g. the API server isn't reachable.
+func (v *DependencyUpdateCheckCustomValidator) validateSpec(ctx context.Context, spec *mmv1alpha1.DependencyUpdateCheckSpec) (field.ErrorList, error) {
+	var allErrs field.ErrorList
+	specPath := field.NewPath("spec")
+
+	namespaces := map[string]bool{}
+	for i, namespace := range spec.Namespaces {
+		namespacePath := specPath.Child("namespaces").Index(i)
+		if namespaces[namespace.Namespace] {
+			allErrs = append(allErrs, field.Duplicate(namespacePath.Child("namespace"), namespace.Namespace))
+		}
+		namespaces[namespace.Namespace] = true
+
+		namespaceErr, err := v.validateNamespaceExists(ctx, namespacePath.Child("namespace"), namespace.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		if namespaceErr != nil {
+			allErrs = append(allErrs, namespaceErr)
+		}
+
+		applications := map[string]bool{}
+		for j, application := range namespace.Applications {
+			applicationPath := namespacePath.Child("applications").Index(j).Child("application")
+			if application.Application == "" {
+				allErrs = append(allErrs, field.Required(applicationPath, "components can only be listed for an application"))
+				continue
+			}
+			if applications[application.Application] {
+				allErrs = append(allErrs, field.Duplicate(applicationPath, application.Application))
+			}
+			applications[application.Application] = true
+		}
+	}
+
+	for i, repository := range spec.Repositories {
+		if repository.CredentialsNamespace == "" {
+			continue
+		}
+		namespaceErr, err := v.validateNamespaceExists(ctx,
+			specPath.Child("repositories").Index(i).Child("credentialsNamespace"), repository.CredentialsNamespace)
+		if err != nil {
+			return nil, err
+		}
+		if namespaceErr != nil {
+			allErrs = append(allErrs, namespaceErr)
+		}
+	}
+
+	selectors := []struct {
+		name     string
+		selector *metav1.LabelSelector
+	}{
+		{"namespaceSelector", spec.NamespaceSelector},
+		{"componentSelector", spec.ComponentSelector},
+		{"applicationSelector", spec.ApplicationSelector},
+	}
+	for _, s := range selectors {
+		if s.selector == nil {
+			continue
+		}
+		if _, err := metav1.LabelSelectorAsSelector(s.selector); err != nil {
+			allErrs = append(allErrs, field.Invalid(specPath.Child(s.name), s.selector, err.Error()))
+		}
+	}
+
+	if spec.Exclude != nil {
+		for i, pattern := range spec.Exclude.Repositories {
+			if _, err := path.Match(pattern, ""); err != nil {
+				allErrs = append(allErrs, field.Invalid(specPath.Child("exclude", "repositories").Index(i), pattern, err.Error()))
+			}
+		}
+	}
+
+	if spec.SpreadOver != nil && spec.SpreadOver.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("spreadOver"), spec.SpreadOver.Duration.String(), "must be positive"))
+	}
+
+	return allErrs, nil
+}
+
+// validateSelectorsMatch returns an error for every selector of the spec if
+// the selectors together don't match any component
+func (v *DependencyUpdateCheckCustomValidator) validateSelectorsMatch(ctx context.Context, spec *mmv1alpha1.DependencyUpdateCheckSpec) (field.ErrorList, error) {
+	if spec.NamespaceSelector == nil && spec.ComponentSelector == nil && spec.ApplicationSelector == nil {
+		return nil, nil
+	}
+
+	components, err := selection.GetCheckComponents(ctx, spec, v.Client)
+	if err != nil {
+		return nil, err
+	}
+	if len(components) > 0 {
+		return nil, nil
+	}
+
+	selectors := []struct {
+		name     string
+		selector *metav1.LabelSelector
+	}{
+		{"namespaceSelector", spec.NamespaceSelector},
+		{"componentSelector", spec.ComponentSelector},
+		{"applicationSelector", spec.ApplicationSelector},
+	}
+	var allErrs field.ErrorList
+	for _, s := range selectors {
+		if s.selector != nil {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec", s.name), s.selector,
+				"namespaceSelector, componentSelector and applicationSelector don't match any component"))
+		}
+	}
+	return allErrs, nil
+}
+
+// isScheduled returns true if the check was created by a DependencyUpdateSchedule
+func isScheduled(check *mmv1alpha1.DependencyUpdateCheck) bool {
+	owner := metav1.GetControllerOf(check)
+	return owner != nil && owner.Kind == "DependencyUpdateSchedule" &&
+		owner.APIVersion == mmv1alpha1.GroupVersion.String()
+}
+
+func (v *DependencyUpdateCheckCustomValidator) validateNamespaceExists(ctx context.Context, fldPath *field.Path, name string) (*field.Error, error) {
+	namespace := &corev1.Namespace{}
+	if err := v.Client.Get(ctx, client.ObjectKey{Name: name}, namespace); err != nil {
+		if apierrors.IsNotFound(err) {
+			return field.NotFound(fldPath, name), nil
+		}
+		return nil, err
+	}
+	return nil, nil
+}
@@ -0,0 +1,191 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	appstudiov1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
+
+	mmv1alpha1 "github.com/konflux-ci/mintmaker/api/v1alpha1"
+	. "github.com/konflux-ci/mintmaker/internal/constant"
+)
+
+var _ = Describe("DependencyUpdateCheck Webhook", func() {
+
+	var (
+		ctx       context.Context
+		validator *DependencyUpdateCheckCustomValidator
+		check     *mmv1alpha1.DependencyUpdateCheck
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		scheme := runtime.NewScheme()
+		utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+		utilruntime.Must(appstudiov1alpha1.AddToScheme(scheme))
+		utilruntime.Must(mmv1alpha1.AddToScheme(scheme))
+
+		component := &appstudiov1alpha1.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "testcomp",
+				Namespace: "testnamespace",
+				Labels:    map[string]string{"team": "a"},
+			},
+			Spec: appstudiov1alpha1.ComponentSpec{Application: "app"},
+		}
+		validator = &DependencyUpdateCheckCustomValidator{
+			Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: MintMakerNamespaceName}},
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "testnamespace", Labels: map[string]string{"tenant": "true"}}},
+				component,
+			).Build(),
+		}
+
+		check = &mmv1alpha1.DependencyUpdateCheck{
+			ObjectMeta: metav1.ObjectMeta{Name: "dependencyupdatecheck-sample", Namespace: MintMakerNamespaceName},
+		}
+	})
+
+	It("should accept a check without any filters", func() {
+		_, err := validator.ValidateCreate(ctx, check)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should reject a check outside the mintmaker namespace", func() {
+		check.Namespace = "testnamespace"
+		_, err := validator.ValidateCreate(ctx, check)
+		Expect(apierrors.IsInvalid(err)).To(BeTrue())
+		Expect(err.Error()).To(ContainSubstring("metadata.namespace"))
+	})
+
+	It("should reject duplicate namespaces", func() {
+		check.Spec.Namespaces = []mmv1alpha1.NamespaceSpec{{Namespace: "testnamespace"}, {Namespace: "testnamespace"}}
+		_, err := validator.ValidateCreate(ctx, check)
+		Expect(apierrors.IsInvalid(err)).To(BeTrue())
+		Expect(err.Error()).To(ContainSubstring("spec.namespaces[1].namespace: Duplicate value"))
+	})
+
+	It("should reject duplicate applications", func() {
+		check.Spec.Namespaces = []mmv1alpha1.NamespaceSpec{{
+			Namespace:    "testnamespace",
+			Applications: []mmv1alpha1.ApplicationSpec{{Application: "app"}, {Application: "app"}},
+		}}
+		_, err := validator.ValidateCreate(ctx, check)
+		Expect(apierrors.IsInvalid(err)).To(BeTrue())
+		Expect(err.Error()).To(ContainSubstring("spec.namespaces[0].applications[1].application: Duplicate value"))
+	})
+
+	It("should reject components listed without an application", func() {
+		check.Spec.Namespaces = []mmv1alpha1.NamespaceSpec{{
+			Namespace:    "testnamespace",
+			Applications: []mmv1alpha1.ApplicationSpec{{Components: []mmv1alpha1.Component{"testcomp"}}},
+		}}
+		_, err := validator.ValidateCreate(ctx, check)
+		Expect(apierrors.IsInvalid(err)).To(BeTrue())
+		Expect(err.Error()).To(ContainSubstring("spec.namespaces[0].applications[0].application: Required value"))
+	})
+
+	It("should reject namespaces which don't exist", func() {
+		check.Spec.Namespaces = []mmv1alpha1.NamespaceSpec{{Namespace: "missing"}}
+		check.Spec.Repositories = []mmv1alpha1.RepositorySpec{{URL: "https://gitlab.com/org/repo", CredentialsNamespace: "missing"}}
+		_, err := validator.ValidateCreate(ctx, check)
+		Expect(apierrors.IsInvalid(err)).To(BeTrue())
+		Expect(err.Error()).To(ContainSubstring("spec.namespaces[0].namespace: Not found"))
+		Expect(err.Error()).To(ContainSubstring("spec.repositories[0].credentialsNamespace: Not found"))
+	})
+
+	It("should reject invalid exclude patterns", func() {
+		check.Spec.Exclude = &mmv1alpha1.ExcludeSpec{Repositories: []string{"github.com/org/[a-"}}
+		_, err := validator.ValidateCreate(ctx, check)
+		Expect(apierrors.IsInvalid(err)).To(BeTrue())
+		Expect(err.Error()).To(ContainSubstring("spec.exclude.repositories[0]"))
+	})
+
+	It("should reject a time window which isn't positive", func() {
+		check.Spec.SpreadOver = &metav1.Duration{Duration: -time.Hour}
+		_, err := validator.ValidateCreate(ctx, check)
+		Expect(apierrors.IsInvalid(err)).To(BeTrue())
+		Expect(err.Error()).To(ContainSubstring("spec.spreadOver"))
+	})
+
+	It("should accept selectors matching a component", func() {
+		check.Spec.NamespaceSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"tenant": "true"}}
+		check.Spec.ComponentSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}}
+		_, err := validator.ValidateCreate(ctx, check)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should reject selectors matching no component", func() {
+		check.Spec.ComponentSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"team": "b"}}
+		_, err := validator.ValidateCreate(ctx, check)
+		Expect(apierrors.IsInvalid(err)).To(BeTrue())
+		Expect(err.Error()).To(ContainSubstring("spec.componentSelector: Invalid value"))
+		Expect(err.Error()).To(ContainSubstring("don't match any component"))
+	})
+
+	It("should accept checks of a schedule with selectors matching no component", func() {
+		check.Spec.ComponentSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"team": "b"}}
+		check.OwnerReferences = []metav1.OwnerReference{{
+			APIVersion: mmv1alpha1.GroupVersion.String(),
+			Kind:       "DependencyUpdateSchedule",
+			Name:       "nightly",
+			UID:        "schedule-uid",
+			Controller: ptr.To(true),
+		}}
+		_, err := validator.ValidateCreate(ctx, check)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should fail if the components can't be looked up", func() {
+		validator.Client = interceptor.NewClient(validator.Client.(client.WithWatch), interceptor.Funcs{
+			List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+				return errors.New("unavailable")
+			},
+		})
+		check.Spec.ComponentSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}}
+		_, err := validator.ValidateCreate(ctx, check)
+		Expect(apierrors.IsInternalError(err)).To(BeTrue())
+	})
+
+	It("should only validate updates changing the spec", func() {
+		check.Spec.Namespaces = []mmv1alpha1.NamespaceSpec{{Namespace: "missing"}}
+		updated := check.DeepCopy()
+		updated.Finalizers = []string{"example.com/finalizer"}
+		_, err := validator.ValidateUpdate(ctx, check, updated)
+		Expect(err).NotTo(HaveOccurred())
+
+		updated.Spec.RerunToken = "1"
+		_, err = validator.ValidateUpdate(ctx, check, updated)
+		Expect(apierrors.IsInvalid(err)).To(BeTrue())
+	})
+})